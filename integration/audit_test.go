@@ -0,0 +1,87 @@
+package integration_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := newServer(t)
+
+	api := newFakeAPI(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+	target := api.AddServer(project, "LT-Siauliai", "B1-1-1gb-20s-shared")
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	err := server.Configure(p.ConfigureRequest{
+		Args: property.NewMap(map[string]property.Value{
+			"token":    property.New("fake-token"),
+			"auditLog": property.New(path),
+		}),
+	})
+	require.NoError(t, err)
+
+	inputs := property.NewMap(map[string]property.Value{
+		"region":  property.New("LT-Siauliai"),
+		"project": property.New(float64(project)),
+	})
+	withPTR := inputs.Set("ptrRecord", property.New("ptr.example.com"))
+
+	integration.LifeCycleTest{
+		Resource: provider.Name + ":network:IP",
+		Create:   integration.Operation{Inputs: inputs},
+		Updates: []integration.Operation{
+			{Inputs: withPTR},
+			{Inputs: withPTR.Set("targetedTo", property.New(float64(target)))},
+			// Removing the target unassigns the address before updating the rest.
+			{Inputs: withPTR},
+		},
+	}.Run(t, server)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	type entry struct {
+		URN     string         `json:"urn"`
+		Type    string         `json:"type"`
+		Action  string         `json:"action"`
+		Status  int            `json:"status"`
+		ID      string         `json:"id"`
+		Payload map[string]any `json:"payload"`
+	}
+
+	var entries []entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, scanner.Err())
+
+	actions := make([]string, 0, len(entries))
+	for _, e := range entries {
+		actions = append(actions, e.Action)
+	}
+	require.Equal(t, []string{"create", "update", "update", "unassign", "update", "delete"}, actions)
+	assert.Equal(t, "ptr.example.com", entries[1].Payload["ptr_record"])
+	assert.Equal(t, map[string]any{"targeted_to": "0"}, entries[3].Payload)
+
+	for _, e := range entries {
+		assert.Equal(t, provider.Name+":network:IP", e.Type)
+		assert.Contains(t, e.URN, "::test")
+		assert.Less(t, e.Status, 300)
+		assert.Equal(t, entries[0].ID, e.ID)
+	}
+}
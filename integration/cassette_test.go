@@ -0,0 +1,442 @@
+package integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cassettes are recordings of API exchanges, so that tests can run offline
+// against the exact payloads of the real API, and catch changes in what the provider sends.
+//
+// Setting CHERRY_RECORD along with credentials records a cassette for each test
+// that calls cassetteTeam, in testdata/<test name>.json. Without credentials,
+// tests that have a cassette replay it. The others fail in CI, so that a missing
+// recording doesn't go unnoticed, and run against the fake API elsewhere.
+// Only tests that make the same requests on each run can use cassettes,
+// so e.g. generated names have to be avoided.
+const (
+	recordVar  = "CHERRY_RECORD"
+	ciVar      = "CI"
+	apiURLVar  = "CHERRY_API_URL"
+	defaultAPI = "https://api.cherryservers.com"
+
+	// replayToken is sent during replay, since the client refuses to run without a token.
+	replayToken = "replay-token"
+	scrubbed    = "REDACTED"
+)
+
+type cassette struct {
+	// Team is the team the resources were created in, which replayed tests need to reuse.
+	Team         int           `json:"team"`
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is a request and its response. The Authorization header isn't recorded,
+// and the token is scrubbed from the bodies.
+type interaction struct {
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// cassetteTeam is like testTeam, but records the test's API exchanges when CHERRY_RECORD is set,
+// and replays them when there are no credentials.
+func cassetteTeam(t *testing.T) int {
+	t.Helper()
+
+	if team, ok := liveTeam(t); ok {
+		if _, record := os.LookupEnv(recordVar); record {
+			recordCassette(t, team, os.Getenv(tokenVar))
+		}
+		return team
+	}
+
+	c, err := loadCassette(cassettePath(t))
+	switch {
+	case err == nil:
+		return replayCassette(t, c)
+	case !errors.Is(err, fs.ErrNotExist):
+		t.Fatalf("failed to load cassette: %v", err)
+	}
+
+	if os.Getenv(ciVar) != "" {
+		t.Fatalf("%s is missing, record it with %s and credentials set", cassettePath(t), recordVar)
+	}
+	t.Logf("%s is missing, running against the fake API", cassettePath(t))
+	return newFakeAPI(t).AddTeam("test")
+}
+
+func cassettePath(t *testing.T) string {
+	t.Helper()
+
+	return filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+}
+
+func loadCassette(path string) (cassette, error) {
+	var c cassette
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, err
+	}
+
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+func (c cassette) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// recordCassette points the provider at a proxy to the API, and saves the exchanges
+// to the test's cassette if it passes.
+func recordCassette(t *testing.T, team int, token string) {
+	t.Helper()
+
+	upstream := defaultAPI
+	if url, ok := os.LookupEnv(apiURLVar); ok {
+		upstream = url
+	}
+
+	rec := newRecorder(upstream, token)
+	proxy := httptest.NewServer(rec)
+	t.Cleanup(proxy.Close)
+	t.Setenv(apiURLVar, proxy.URL)
+
+	path := cassettePath(t)
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+
+		c := rec.cassette()
+		c.Team = team
+		if err := c.save(path); err != nil {
+			t.Errorf("failed to save cassette: %v", err)
+		}
+	})
+}
+
+// replayCassette points the provider at a server that answers with the recorded responses,
+// and returns the team they were recorded in.
+func replayCassette(t *testing.T, c cassette) int {
+	t.Helper()
+
+	rep := newReplayer(c.Interactions, t.Errorf)
+	server := httptest.NewServer(rep)
+	t.Cleanup(server.Close)
+	t.Cleanup(rep.finish)
+
+	t.Setenv(apiURLVar, server.URL)
+	t.Setenv(tokenVar, replayToken)
+
+	return c.Team
+}
+
+// recorder proxies requests to the API, recording each exchange.
+type recorder struct {
+	upstream string
+	token    string
+
+	mu           sync.Mutex
+	interactions []interaction
+}
+
+func newRecorder(upstream, token string) *recorder {
+	return &recorder{upstream: strings.TrimSuffix(upstream, "/"), token: token}
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	reqBody, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, r.upstream+req.URL.RequestURI(),
+		bytes.NewReader(reqBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	out.Header = req.Header.Clone()
+
+	resp, err := http.DefaultClient.Do(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, interaction{
+		Method:   req.Method,
+		Path:     req.URL.RequestURI(),
+		Request:  r.scrub(reqBody),
+		Status:   resp.StatusCode,
+		Response: r.scrub(respBody),
+	})
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(respBody)
+}
+
+// scrub removes the token from a body, and compacts it if it's JSON.
+func (r *recorder) scrub(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if r.token != "" {
+		body = bytes.ReplaceAll(body, []byte(r.token), []byte(scrubbed))
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, body); err != nil {
+		// Not JSON, so store it as a string.
+		quoted, _ := json.Marshal(string(body))
+		return quoted
+	}
+	return buf.Bytes()
+}
+
+func (r *recorder) cassette() cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return cassette{Interactions: r.interactions}
+}
+
+// replayer answers requests with recorded responses, in the order they were recorded.
+// Requests that don't match the recording are reported, and answered with an error.
+type replayer struct {
+	errorf func(format string, args ...any)
+
+	mu           sync.Mutex
+	interactions []interaction
+	next         int
+}
+
+func newReplayer(interactions []interaction, errorf func(format string, args ...any)) *replayer {
+	return &replayer{interactions: interactions, errorf: errorf}
+}
+
+func (r *replayer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	got := interaction{Method: req.Method, Path: req.URL.RequestURI(), Request: body}
+
+	if r.next >= len(r.interactions) {
+		r.mismatch(w, fmt.Sprintf("unexpected request %s, after all %d recorded ones",
+			describe(got), len(r.interactions)))
+		return
+	}
+
+	want := r.interactions[r.next]
+	if err = matches(got, want); err != nil {
+		r.mismatch(w, fmt.Sprintf("request %d: %v\n got: %s\nwant: %s", r.next, err, describe(got), describe(want)))
+		return
+	}
+	r.next++
+
+	if want.Response != nil {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(want.Status)
+	_, _ = w.Write(rawBody(want.Response))
+}
+
+// mismatch answers with a status that isn't retried, so the mismatch surfaces right away.
+func (r *replayer) mismatch(w http.ResponseWriter, msg string) {
+	r.errorf("cassette mismatch: %s", msg)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTeapot)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    http.StatusTeapot,
+		"message": "cassette mismatch: " + msg,
+	})
+}
+
+// finish reports recorded requests that were never made.
+func (r *replayer) finish() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next < len(r.interactions) {
+		r.errorf("cassette mismatch: %d recorded requests weren't made, starting with %s",
+			len(r.interactions)-r.next, describe(r.interactions[r.next]))
+	}
+}
+
+// matches compares requests by method, path and body. JSON bodies are compared by value,
+// so key order and formatting don't matter.
+func matches(got, want interaction) error {
+	if got.Method != want.Method || got.Path != want.Path {
+		return errors.New("different endpoint")
+	}
+
+	gotBody, wantBody := decodeBody(got.Request), decodeBody(want.Request)
+	if !reflect.DeepEqual(gotBody, wantBody) {
+		return errors.New("different body")
+	}
+	return nil
+}
+
+func decodeBody(body json.RawMessage) any {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+	return v
+}
+
+// rawBody undoes the quoting of bodies that weren't JSON.
+func rawBody(body json.RawMessage) []byte {
+	var str string
+	if err := json.Unmarshal(body, &str); err == nil {
+		return []byte(str)
+	}
+	return body
+}
+
+func describe(i interaction) string {
+	if len(i.Request) == 0 {
+		return i.Method + " " + i.Path
+	}
+	return fmt.Sprintf("%s %s %s", i.Method, i.Path, bytes.TrimSpace(i.Request))
+}
+
+// projectLifecycle creates, updates and deletes a project with a fixed name,
+// so its requests are the same each time.
+func projectLifecycle(team int) integration.LifeCycleTest {
+	const name = "pulumi-test-cassette"
+
+	return integration.LifeCycleTest{
+		Resource: provider.Name + ":index:Project",
+		Create: integration.Operation{
+			Inputs: property.NewMap(map[string]property.Value{
+				"name": property.New(name),
+				"team": property.New(float64(team)),
+			}),
+		},
+		Updates: []integration.Operation{
+			{
+				Inputs: property.NewMap(map[string]property.Value{
+					"name": property.New(name + "-updated"),
+					"team": property.New(float64(team)),
+					"bgp":  property.New(true),
+				}),
+			},
+		},
+	}
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	api := newFakeAPI(t)
+	team := api.AddTeam("test")
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(tokenVar, "secret-token")
+
+		rec := newRecorder(api.URL, "secret-token")
+		proxy := httptest.NewServer(rec)
+		defer proxy.Close()
+		t.Setenv(apiURLVar, proxy.URL)
+
+		projectLifecycle(team).Run(t, newServer(t))
+
+		c := rec.cassette()
+		c.Team = team
+		require.NoError(t, c.save(path))
+	})
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+
+	c, err := loadCassette(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, c.Interactions)
+
+	// Nothing reaches the fake API during replay.
+	api.Close()
+
+	t.Run("replay", func(t *testing.T) {
+		projectLifecycle(replayCassette(t, c)).Run(t, newServer(t))
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		var errs []string
+		rep := newReplayer(c.Interactions, func(format string, args ...any) {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		})
+		server := httptest.NewServer(rep)
+		defer server.Close()
+
+		first := c.Interactions[0]
+		req, reqErr := http.NewRequestWithContext(t.Context(), http.MethodDelete, server.URL+first.Path, nil)
+		require.NoError(t, reqErr)
+
+		resp, reqErr := http.DefaultClient.Do(req)
+		require.NoError(t, reqErr)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+		rep.finish()
+
+		if assert.Len(t, errs, 2) {
+			assert.Contains(t, errs[0], "different endpoint")
+			assert.Contains(t, errs[1], fmt.Sprintf("%d recorded requests weren't made", len(c.Interactions)))
+		}
+	})
+}
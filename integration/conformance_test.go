@@ -0,0 +1,242 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suite runs the tests every resource needs against the fake API:
+// create with the required inputs and with all of them, update each mutable input,
+// replace on each immutable one, delete a resource that's already gone and read a missing one.
+// A resource only declares its inputs, with whether changing them replaces it.
+
+// conformance declares a resource for the conformance suite.
+type conformance struct {
+	// resource is the type token without the package, e.g. "network:IP".
+	resource string
+	// inputs must include every input of the resource, which the suite checks against the schema.
+	inputs []conformanceInput
+}
+
+type conformanceInput struct {
+	name     string
+	required bool
+	// replaces is whether changing the input replaces the resource, instead of updating it.
+	replaces bool
+	// value is set when creating the resource with all inputs, unless it's null.
+	value property.Value
+	// changed is what the input is changed to.
+	changed property.Value
+	// drops are the inputs to remove when changing this one,
+	// because they conflict with it or wouldn't be valid along with it.
+	drops []string
+}
+
+// setupConformance seeds a fake API with what a resource needs, and declares it.
+// It must declare the same inputs, in the same order, for every fake API.
+type setupConformance func(api *fakeapi.Server) conformance
+
+func (c conformance) token() tokens.Type {
+	return tokens.Type(provider.Name + ":" + c.resource)
+}
+
+func (c conformance) required() property.Map {
+	m := map[string]property.Value{}
+	for _, in := range c.inputs {
+		if in.required {
+			m[in.name] = in.value
+		}
+	}
+	return property.NewMap(m)
+}
+
+func (c conformance) all() property.Map {
+	m := map[string]property.Value{}
+	for _, in := range c.inputs {
+		if !in.value.IsNull() {
+			m[in.name] = in.value
+		}
+	}
+	return property.NewMap(m)
+}
+
+// changed returns all inputs, with one of them changed.
+func (c conformance) changed(in conformanceInput) property.Map {
+	m := c.all().AsMap()
+	for _, name := range in.drops {
+		delete(m, name)
+	}
+	m[in.name] = in.changed
+	return property.NewMap(m)
+}
+
+// runConformance runs the conformance suite, each test against a new fake API.
+func runConformance(t *testing.T, setup setupConformance) {
+	t.Helper()
+
+	start := func(t *testing.T) (integration.Server, conformance) {
+		t.Helper()
+
+		server := newServer(t)
+		return server, setup(newFakeAPI(t))
+	}
+
+	t.Run("declares every input", func(t *testing.T) {
+		server, c := start(t)
+		checkDeclaresInputs(t, server, c)
+	})
+
+	t.Run("create with required inputs", func(t *testing.T) {
+		server, c := start(t)
+		integration.LifeCycleTest{
+			Resource: c.token(),
+			Create:   integration.Operation{Inputs: c.required(), Hook: outputsMatch(t, c.required())},
+		}.Run(t, server)
+	})
+
+	t.Run("create with all inputs", func(t *testing.T) {
+		server, c := start(t)
+		integration.LifeCycleTest{
+			Resource: c.token(),
+			Create:   integration.Operation{Inputs: c.all(), Hook: outputsMatch(t, c.all())},
+		}.Run(t, server)
+	})
+
+	for i, declared := range setup(newFakeAPI(t)).inputs {
+		name := "update " + declared.name
+		if declared.replaces {
+			name = "replace on " + declared.name
+		}
+
+		t.Run(name, func(t *testing.T) {
+			server, c := start(t)
+			in := c.inputs[i]
+			changed := c.changed(in)
+
+			integration.LifeCycleTest{
+				Resource: c.token(),
+				Create: integration.Operation{
+					Inputs: c.all(),
+					Hook: func(_, output property.Map) {
+						checkDiffKind(t, server, c, in, output, changed)
+					},
+				},
+				Updates: []integration.Operation{
+					{
+						Inputs: changed,
+						Hook:   outputsMatch(t, property.NewMap(map[string]property.Value{in.name: in.changed})),
+					},
+					// Changing it back makes sure the resource can be deleted, e.g. after enabling deletion protection.
+					{Inputs: c.all(), Hook: outputsMatch(t, c.all())},
+				},
+			}.Run(t, server)
+		})
+	}
+
+	t.Run("delete when already gone", func(t *testing.T) {
+		server, c := start(t)
+		id, state := createRequired(t, server, c)
+
+		require.NoError(t, server.Delete(p.DeleteRequest{ID: id, Urn: urn(c.resource, "test"), Properties: state}))
+		assert.NoError(t, server.Delete(p.DeleteRequest{ID: id, Urn: urn(c.resource, "test"), Properties: state}))
+	})
+
+	t.Run("read when missing", func(t *testing.T) {
+		server, c := start(t)
+		id, state := createRequired(t, server, c)
+		require.NoError(t, server.Delete(p.DeleteRequest{ID: id, Urn: urn(c.resource, "test"), Properties: state}))
+
+		resp, err := server.Read(p.ReadRequest{ID: id, Urn: urn(c.resource, "test"), Properties: state})
+		require.NoError(t, err)
+		assert.Empty(t, resp.ID, "a missing resource must be read as gone")
+	})
+}
+
+// checkDeclaresInputs checks that the declared inputs are the ones in the schema.
+func checkDeclaresInputs(t *testing.T, server integration.Server, c conformance) {
+	t.Helper()
+
+	resp, err := server.GetSchema(p.GetSchemaRequest{})
+	require.NoError(t, err)
+
+	var spec struct {
+		Resources map[string]struct {
+			InputProperties map[string]json.RawMessage `json:"inputProperties"`
+			RequiredInputs  []string                   `json:"requiredInputs"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(resp.Schema), &spec))
+
+	res, ok := spec.Resources[string(c.token())]
+	require.True(t, ok, "%s isn't in the schema", c.token())
+
+	var inputs, required []string
+	for _, in := range c.inputs {
+		inputs = append(inputs, in.name)
+		if in.required {
+			required = append(required, in.name)
+		}
+	}
+
+	var schemaInputs []string
+	for name := range res.InputProperties {
+		schemaInputs = append(schemaInputs, name)
+	}
+	assert.ElementsMatch(t, schemaInputs, inputs, "declared inputs")
+	assert.ElementsMatch(t, res.RequiredInputs, required, "declared required inputs")
+}
+
+// checkDiffKind checks that changing the input updates or replaces the resource, as declared.
+func checkDiffKind(
+	t *testing.T, server integration.Server, c conformance, in conformanceInput, state, changed property.Map,
+) {
+	t.Helper()
+
+	u := urn(c.resource, "test")
+	check, err := server.Check(p.CheckRequest{Urn: u, State: c.all(), Inputs: changed})
+	require.NoError(t, err)
+	require.Empty(t, check.Failures)
+
+	diff, err := server.Diff(p.DiffRequest{ID: "test", Urn: u, State: state, Inputs: check.Inputs, OldInputs: c.all()})
+	require.NoError(t, err)
+	require.Contains(t, diff.DetailedDiff, in.name)
+
+	replaces := slices.Contains([]p.DiffKind{p.AddReplace, p.UpdateReplace, p.DeleteReplace},
+		diff.DetailedDiff[in.name].Kind)
+	assert.Equal(t, in.replaces, replaces, "whether changing %s replaces the resource", in.name)
+}
+
+// outputsMatch returns a hook that checks that the outputs have the expected values.
+func outputsMatch(t *testing.T, want property.Map) func(_, output property.Map) {
+	t.Helper()
+
+	return func(_, output property.Map) {
+		for name, value := range want.All {
+			assert.True(t, output.Get(name).Equals(value), "%s is %#v, want %#v", name, output.Get(name), value)
+		}
+	}
+}
+
+// createRequired creates the resource with its required inputs, and returns its ID and state.
+func createRequired(t *testing.T, server integration.Server, c conformance) (string, property.Map) {
+	t.Helper()
+
+	u := urn(c.resource, "test")
+	check, err := server.Check(p.CheckRequest{Urn: u, Inputs: c.required()})
+	require.NoError(t, err)
+	require.Empty(t, check.Failures)
+
+	resp, err := server.Create(p.CreateRequest{Urn: u, Properties: check.Inputs})
+	require.NoError(t, err)
+	return resp.ID, resp.Properties
+}
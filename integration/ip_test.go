@@ -0,0 +1,161 @@
+package integration_test
+
+import (
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+)
+
+// IP addresses are billed even when unused, so this only runs against the fake API.
+func TestIPLifecycle(t *testing.T) {
+	server := newServer(t)
+
+	api := newFakeAPI(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+
+	inputs := func(ptr string, tags map[string]string) property.Map {
+		m := map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(float64(project)),
+		}
+		if ptr != "" {
+			m["ptrRecord"] = property.New(ptr)
+		}
+		if tags != nil {
+			values := map[string]property.Value{}
+			for k, v := range tags {
+				values[k] = property.New(v)
+			}
+			m["tags"] = property.New(property.NewMap(values))
+		}
+		return property.NewMap(m)
+	}
+
+	integration.LifeCycleTest{
+		Resource: provider.Name + ":network:IP",
+		Create: integration.Operation{
+			Inputs: inputs("ptr.example.com", map[string]string{"env": "test"}),
+			Hook: func(_, output property.Map) {
+				assert.Equal(t, "ptr.example.com", output.Get("ptrRecord").AsString())
+				assert.Equal(t, "test", output.Get("tags").AsMap().Get("env").AsString())
+				assert.Equal(t, "floating-ip", output.Get("type").AsString())
+				assert.NotEmpty(t, output.Get("address").AsString())
+
+				ips := api.IPAddresses()
+				if assert.Len(t, ips, 1) {
+					assert.Equal(t, "ptr.example.com", ips[0].PtrRecord)
+				}
+			},
+		},
+		Updates: []integration.Operation{
+			{
+				// Removed inputs are cleared.
+				Inputs: inputs("", nil),
+				Hook: func(_, output property.Map) {
+					_, ok := output.GetOk("ptrRecord")
+					assert.True(t, !ok || output.Get("ptrRecord").AsString() == "")
+
+					ips := api.IPAddresses()
+					if assert.Len(t, ips, 1) {
+						assert.Empty(t, ips[0].PtrRecord)
+						assert.Empty(t, *ips[0].Tags)
+					}
+				},
+			},
+		},
+	}.Run(t, server)
+
+	assert.Empty(t, api.IPAddresses())
+}
+
+// TestIPLifecycleInNewProject replays its cassette when there are no credentials, see cassetteTeam.
+// Recording it bills for an IP address, for as long as the test runs.
+func TestIPLifecycleInNewProject(t *testing.T) {
+	server := newServer(t)
+
+	team := cassetteTeam(t)
+	project, deleteProject := createProject(t, server, team, "pulumi-test-ip-lifecycle")
+	defer deleteProject()
+
+	base := property.NewMap(map[string]property.Value{
+		"region":  property.New("LT-Siauliai"),
+		"project": property.New(float64(project)),
+	})
+
+	integration.LifeCycleTest{
+		Resource: provider.Name + ":network:IP",
+		Create: integration.Operation{
+			Inputs: base.Set("ptrRecord", property.New("ptr.example.com")),
+			Hook: func(_, output property.Map) {
+				assert.Equal(t, "ptr.example.com", output.Get("ptrRecord").AsString())
+				assert.Equal(t, project, int(output.Get("project").AsNumber()))
+				assert.NotEmpty(t, output.Get("address").AsString())
+			},
+		},
+		Updates: []integration.Operation{
+			{
+				Inputs: base.Set("tags", property.New(map[string]property.Value{"env": property.New("test")})),
+				Hook: func(_, output property.Map) {
+					_, ok := output.GetOk("ptrRecord")
+					assert.True(t, !ok || output.Get("ptrRecord").AsString() == "")
+					assert.Equal(t, "test", output.Get("tags").AsMap().Get("env").AsString())
+				},
+			},
+		},
+	}.Run(t, server)
+}
+
+func TestIPConformance(t *testing.T) {
+	runConformance(t, func(api *fakeapi.Server) conformance {
+		team := api.AddTeam("test")
+		project := api.AddProject(team, "test")
+		tags := func(env string) property.Value {
+			return property.New(map[string]property.Value{"env": property.New(env)})
+		}
+
+		return conformance{
+			resource: "network:IP",
+			inputs: []conformanceInput{
+				{
+					name: "region", required: true, replaces: true,
+					value: property.New("LT-Siauliai"), changed: property.New("NL-Amsterdam"),
+					// The server is in the old region.
+					drops: []string{"targetedTo"},
+				},
+				{
+					name: "project", required: true, replaces: true,
+					value:   property.New(float64(project)),
+					changed: property.New(float64(api.AddProject(team, "other"))),
+					// The server is in the old project.
+					drops: []string{"targetedTo"},
+				},
+				{
+					name:  "ptrRecord",
+					value: property.New("ptr.example.com"), changed: property.New("ptr-changed.example.com"),
+				},
+				{
+					name:  "aRecord",
+					value: property.New("a.example.com"), changed: property.New("a-changed.example.com"),
+				},
+				{
+					// An IP is either routed or targeted, and it's targeted when created with all inputs.
+					name:    "routedTo",
+					changed: property.New(api.AddIP(project, "LT-Siauliai")),
+					drops:   []string{"targetedTo"},
+				},
+				{
+					name:    "targetedTo",
+					value:   property.New(float64(api.AddServer(project, "LT-Siauliai", "B1-1-1gb-20s-shared"))),
+					changed: property.New(float64(api.AddServer(project, "LT-Siauliai", "B1-1-1gb-20s-shared"))),
+				},
+				{name: "tags", value: tags("test"), changed: tags("prod")},
+				{name: "deletionProtection", value: property.New(false), changed: property.New(true)},
+				{name: "retainOnDelete", value: property.New(false), changed: property.New(true)},
+			},
+		}
+	})
+}
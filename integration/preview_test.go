@@ -0,0 +1,119 @@
+package integration_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Previews don't call the API, so these run without credentials.
+
+func urn(typ, name string) resource.URN {
+	return resource.NewURN("test", "test", "", tokens.Type(provider.Name+":"+typ), name)
+}
+
+func TestIPCreatePreviewUnknownOutputs(t *testing.T) {
+	server := newServer(t)
+
+	resp, err := server.Create(p.CreateRequest{
+		Urn: urn("network:IP", "ip"),
+		Properties: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(1.0),
+		}),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	for _, k := range []string{"address", "addressFamily", "cidr", "type"} {
+		assert.True(t, resp.Properties.Get(k).IsComputed(), "%s should be unknown", k)
+	}
+	assert.Equal(t, "LT-Siauliai", resp.Properties.Get("region").AsString())
+}
+
+func TestIPUpdatePreviewKeepsOutputs(t *testing.T) {
+	server := newServer(t)
+
+	inputs := map[string]property.Value{
+		"region":  property.New("LT-Siauliai"),
+		"project": property.New(1.0),
+	}
+	state := map[string]property.Value{
+		"address":       property.New("5.199.171.1"),
+		"addressFamily": property.New(4.0),
+		"cidr":          property.New("5.199.171.1/32"),
+		"type":          property.New("floating-ip"),
+	}
+	maps.Copy(state, inputs)
+
+	newInputs := property.NewMap(inputs).Set("ptrRecord", property.New("ptr.example.com"))
+
+	resp, err := server.Update(p.UpdateRequest{
+		ID:        "ip-1",
+		Urn:       urn("network:IP", "ip"),
+		State:     property.NewMap(state),
+		OldInputs: property.NewMap(inputs),
+		Inputs:    newInputs,
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "5.199.171.1", resp.Properties.Get("address").AsString())
+	assert.Equal(t, "5.199.171.1/32", resp.Properties.Get("cidr").AsString())
+	assert.Equal(t, "floating-ip", resp.Properties.Get("type").AsString())
+	assert.Equal(t, "ptr.example.com", resp.Properties.Get("ptrRecord").AsString())
+}
+
+func TestProjectPreviewLocalASN(t *testing.T) {
+	server := newServer(t)
+
+	create, err := server.Create(p.CreateRequest{
+		Urn: urn("index:Project", "project"),
+		Properties: property.NewMap(map[string]property.Value{
+			"name": property.New("test"),
+			"team": property.New(1.0),
+			"bgp":  property.New(true),
+		}),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, create.Properties.Get("localASN").IsComputed())
+
+	inputs := property.NewMap(map[string]property.Value{
+		"name": property.New("test"),
+		"team": property.New(1.0),
+		"bgp":  property.New(true),
+	})
+	state := inputs.Set("localASN", property.New(65000.0))
+
+	// Renaming keeps the assigned ASN.
+	renamed, err := server.Update(p.UpdateRequest{
+		ID:        "1",
+		Urn:       urn("index:Project", "project"),
+		State:     state,
+		OldInputs: inputs,
+		Inputs:    inputs.Set("name", property.New("renamed")),
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 65000, int(renamed.Properties.Get("localASN").AsNumber()))
+
+	// Toggling BGP might change it.
+	toggled, err := server.Update(p.UpdateRequest{
+		ID:        "1",
+		Urn:       urn("index:Project", "project"),
+		State:     state,
+		OldInputs: inputs,
+		Inputs:    inputs.Set("bgp", property.New(false)),
+		DryRun:    true,
+	})
+	require.NoError(t, err)
+	assert.True(t, toggled.Properties.Get("localASN").IsComputed())
+}
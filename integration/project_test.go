@@ -5,36 +5,95 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
 	"github.com/caliban0/pulumi-cherry-servers/provider"
+	p "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/integration"
 	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func teamFromEnv(t *testing.T) int {
+const (
+	tokenVar = "CHERRY_AUTH_TOKEN"
+	teamVar  = "CHERRY_TEAM_ID"
+)
+
+// testTeam returns the team to create resources in. Tests run against the real API
+// when credentials are set, and against a fake one otherwise.
+func testTeam(t *testing.T) int {
 	t.Helper()
 
-	const teamVar = "CHERRY_TEAM_ID"
+	if team, ok := liveTeam(t); ok {
+		return team
+	}
+
+	return newFakeAPI(t).AddTeam("test")
+}
+
+// liveTeam returns the team from the environment, if credentials for the real API are set.
+func liveTeam(t *testing.T) (int, bool) {
+	t.Helper()
 
-	teamRaw, ok := os.LookupEnv(teamVar)
-	if !ok {
-		t.Fatalf("%s not set", teamVar)
+	_, hasToken := os.LookupEnv(tokenVar)
+	teamRaw, hasTeam := os.LookupEnv(teamVar)
+	if !hasToken || !hasTeam {
+		return 0, false
 	}
 
 	team, err := strconv.Atoi(teamRaw)
 	if err != nil {
 		t.Fatalf("failed to parse %s: %v", teamVar, err)
 	}
-	return team
+	return team, true
+}
+
+// newFakeAPI points the provider at a fake API for the duration of the test.
+func newFakeAPI(t *testing.T) *fakeapi.Server {
+	t.Helper()
+
+	api := fakeapi.New()
+	t.Cleanup(api.Close)
+
+	t.Setenv(apiURLVar, api.URL)
+	t.Setenv(tokenVar, "fake-token")
+
+	return api
+}
+
+// createProject creates a project through the provider, and returns its ID and a function that deletes it.
+// The project has to be deleted before the test ends, while the server is still running.
+func createProject(t *testing.T, server integration.Server, team int, name string) (int, func()) {
+	t.Helper()
+
+	u := urn("index:Project", name)
+	check, err := server.Check(p.CheckRequest{
+		Urn: u,
+		Inputs: property.NewMap(map[string]property.Value{
+			"team": property.New(float64(team)),
+			"name": property.New(name),
+		}),
+	})
+	require.NoError(t, err)
+	require.Empty(t, check.Failures)
+
+	resp, err := server.Create(p.CreateRequest{Urn: u, Properties: check.Inputs})
+	require.NoError(t, err)
+	id, err := strconv.Atoi(resp.ID)
+	require.NoError(t, err)
+
+	return id, func() {
+		assert.NoError(t, server.Delete(p.DeleteRequest{ID: resp.ID, Urn: u, Properties: resp.Properties}))
+	}
 }
 
 func TestProjectLifecycleWithOnlyRequiredArgs(t *testing.T) {
 	server := newServer(t)
 
-	team := teamFromEnv(t)
+	team := testTeam(t)
 
 	integration.LifeCycleTest{
-		Resource: provider.Name + ":provider:Project",
+		Resource: provider.Name + ":index:Project",
 		Create: integration.Operation{
 			Inputs: property.NewMap(map[string]property.Value{
 				"team": property.New(float64(team)),
@@ -42,7 +101,7 @@ func TestProjectLifecycleWithOnlyRequiredArgs(t *testing.T) {
 			Hook: func(_, output property.Map) {
 				assert.Regexp(t, "test-([a-f]|[0-9]){6}", output.Get("name").AsString())
 				assert.Equal(t, team, int(output.Get("team").AsNumber()))
-				assert.False(t, output.Get("bgp").AsMap().Get("enabled").AsBool())
+				assert.False(t, output.Get("bgp").AsBool())
 			},
 		},
 	}.Run(t, server)
@@ -52,10 +111,10 @@ func TestProjectLifecycleWithOptionalArgs(t *testing.T) {
 	server := newServer(t)
 
 	const name = "pulumi-test-project-optionals"
-	team := teamFromEnv(t)
+	team := cassetteTeam(t)
 
 	integration.LifeCycleTest{
-		Resource: provider.Name + ":provider:Project",
+		Resource: provider.Name + ":index:Project",
 		Create: integration.Operation{
 			Inputs: property.NewMap(map[string]property.Value{
 				"name": property.New(name),
@@ -65,7 +124,7 @@ func TestProjectLifecycleWithOptionalArgs(t *testing.T) {
 			Hook: func(_, output property.Map) {
 				assert.Equal(t, name, output.Get("name").AsString())
 				assert.Equal(t, team, int(output.Get("team").AsNumber()))
-				assert.True(t, output.Get("bgp").AsMap().Get("enabled").AsBool())
+				assert.True(t, output.Get("bgp").AsBool())
 			},
 		},
 		Updates: []integration.Operation{
@@ -78,9 +137,28 @@ func TestProjectLifecycleWithOptionalArgs(t *testing.T) {
 				Hook: func(_, output property.Map) {
 					assert.Equal(t, name+"-updated", output.Get("name").AsString())
 					assert.Equal(t, team, int(output.Get("team").AsNumber()))
-					assert.False(t, output.Get("bgp").AsMap().Get("enabled").AsBool())
+					assert.False(t, output.Get("bgp").AsBool())
 				},
 			},
 		},
 	}.Run(t, server)
 }
+
+func TestProjectConformance(t *testing.T) {
+	runConformance(t, func(api *fakeapi.Server) conformance {
+		return conformance{
+			resource: "index:Project",
+			inputs: []conformanceInput{
+				{
+					name: "team", required: true, replaces: true,
+					value:   property.New(float64(api.AddTeam("test"))),
+					changed: property.New(float64(api.AddTeam("other"))),
+				},
+				{name: "name", value: property.New("conformance"), changed: property.New("conformance-renamed")},
+				{name: "bgp", value: property.New(false), changed: property.New(true)},
+				{name: "deletionProtection", value: property.New(false), changed: property.New(true)},
+				{name: "forceDestroy", value: property.New(false), changed: property.New(true)},
+			},
+		}
+	})
+}
@@ -0,0 +1,351 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+	"testing/quick"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+)
+
+// Round-trip tests generate random valid inputs, and check that what the provider
+// writes to the API is what it reads back: a resource that was just created or updated
+// has no diff against its inputs.
+
+// The inputs are random each run, unless CHERRY_ROUNDTRIP_SEED is set,
+// e.g. to the seed a failed run logs, to rerun it with the same ones.
+const (
+	roundTripCases   = 25
+	roundTripSeedVar = "CHERRY_ROUNDTRIP_SEED"
+)
+
+func roundTripConfig(t *testing.T) *quick.Config {
+	t.Helper()
+
+	seed := time.Now().UnixNano()
+	if s, ok := os.LookupEnv(roundTripSeedVar); ok {
+		var err error
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			t.Fatalf("invalid %s: %v", roundTripSeedVar, err)
+		}
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("rerun with %s=%d", roundTripSeedVar, seed)
+		}
+	})
+
+	return &quick.Config{MaxCount: roundTripCases, Rand: rand.New(rand.NewSource(seed))}
+}
+
+// newRoundTripServer returns a server that keeps running for the cleanups of the test,
+// which delete the resources the round trips create.
+func newRoundTripServer(t *testing.T) integration.Server {
+	t.Helper()
+
+	return newServerContext(context.WithoutCancel(t.Context()), t)
+}
+
+// checkRoundTrip creates a resource, reads it and diffs it against its inputs,
+// then does the same after updating it to other inputs.
+// The resource is deleted when the test ends.
+func checkRoundTrip(t *testing.T, server integration.Server, urn resource.URN, create, update property.Map) error {
+	t.Helper()
+
+	checked, err := check(server, urn, property.Map{}, create)
+	if err != nil {
+		return err
+	}
+
+	created, err := server.Create(p.CreateRequest{Urn: urn, Properties: checked})
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	last := created.Properties
+	t.Cleanup(func() {
+		// Protection and retention would keep the resource around, so they're left out of the state.
+		state := last.Delete("deletionProtection", "retainOnDelete")
+		if delErr := server.Delete(p.DeleteRequest{ID: created.ID, Urn: urn, Properties: state}); delErr != nil {
+			t.Errorf("failed to delete %s: %v", created.ID, delErr)
+		}
+	})
+
+	state, inputs, err := readAndDiff(server, urn, created.ID, created.Properties, checked)
+	if err != nil {
+		return fmt.Errorf("after create: %w", err)
+	}
+
+	checked, err = check(server, urn, inputs, update)
+	if err != nil {
+		return err
+	}
+
+	updated, err := server.Update(p.UpdateRequest{
+		ID:        created.ID,
+		Urn:       urn,
+		State:     state,
+		Inputs:    checked,
+		OldInputs: inputs,
+	})
+	if err != nil {
+		return fmt.Errorf("update: %w", err)
+	}
+	last = updated.Properties
+
+	if _, _, err = readAndDiff(server, urn, created.ID, updated.Properties, checked); err != nil {
+		return fmt.Errorf("after update: %w", err)
+	}
+	return nil
+}
+
+func check(server integration.Server, urn resource.URN, old, inputs property.Map) (property.Map, error) {
+	resp, err := server.Check(p.CheckRequest{Urn: urn, State: old, Inputs: inputs})
+	if err != nil {
+		return property.Map{}, fmt.Errorf("check: %w", err)
+	}
+	if len(resp.Failures) > 0 {
+		return property.Map{}, fmt.Errorf("check failed: %v", resp.Failures)
+	}
+	return resp.Inputs, nil
+}
+
+// readAndDiff reads the resource, and checks that the inputs it reads back are the ones it was given,
+// and that diffing the read state against them shows no changes.
+func readAndDiff(
+	server integration.Server, urn resource.URN, id string, state, inputs property.Map,
+) (property.Map, property.Map, error) {
+	read, err := server.Read(p.ReadRequest{ID: id, Urn: urn, Properties: state, Inputs: inputs})
+	if err != nil {
+		return property.Map{}, property.Map{}, fmt.Errorf("read: %w", err)
+	}
+
+	for key, want := range inputs.All {
+		if got := read.Inputs.Get(key); !got.Equals(want) {
+			return property.Map{}, property.Map{}, fmt.Errorf("read %s as %#v, want %#v", key, got, want)
+		}
+	}
+
+	diff, err := server.Diff(p.DiffRequest{
+		ID:        id,
+		Urn:       urn,
+		State:     read.Properties,
+		Inputs:    inputs,
+		OldInputs: read.Inputs,
+	})
+	if err != nil {
+		return property.Map{}, property.Map{}, fmt.Errorf("diff: %w", err)
+	}
+	if diff.HasChanges {
+		return property.Map{}, property.Map{}, fmt.Errorf("diff has changes: %v", diff.DetailedDiff)
+	}
+
+	return read.Properties, read.Inputs, nil
+}
+
+func randomHostname(r *rand.Rand) string {
+	const letters = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	label := make([]byte, 1+r.Intn(10))
+	for i := range label {
+		label[i] = letters[r.Intn(len(letters))]
+	}
+	return string(label) + ".example.com"
+}
+
+// maybe returns an empty value a third of the time, so that optional inputs are left out.
+func maybe(r *rand.Rand, value func(*rand.Rand) string) string {
+	if r.Intn(3) == 0 {
+		return ""
+	}
+	return value(r)
+}
+
+func randomTags(r *rand.Rand) map[string]string {
+	keys := []string{"env", "team", "tier", "app", "owner"}
+
+	n := r.Intn(len(keys) + 1)
+	if n == 0 {
+		return nil
+	}
+
+	tags := map[string]string{}
+	for _, i := range r.Perm(len(keys))[:n] {
+		tags[keys[i]] = fmt.Sprintf("value-%d", r.Intn(100))
+	}
+	return tags
+}
+
+// IP address assignments.
+const (
+	unassigned = iota
+	routed
+	targeted
+	assignments
+)
+
+// ipInputs are random inputs for an IP address, with the region and project left to the test.
+type ipInputs struct {
+	PTRRecord          string
+	ARecord            string
+	Tags               map[string]string
+	Assignment         int
+	DeletionProtection bool
+	RetainOnDelete     bool
+}
+
+func (ipInputs) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(ipInputs{
+		PTRRecord:          maybe(r, randomHostname),
+		ARecord:            maybe(r, randomHostname),
+		Tags:               randomTags(r),
+		Assignment:         r.Intn(assignments),
+		DeletionProtection: r.Intn(2) == 0,
+		RetainOnDelete:     r.Intn(2) == 0,
+	})
+}
+
+// ipEnv is what IP addresses are created in, and can be assigned to.
+type ipEnv struct {
+	project int
+	region  string
+	routeTo string
+	server  int
+}
+
+func (i ipInputs) toMap(env ipEnv) property.Map {
+	m := map[string]property.Value{
+		"region":  property.New(env.region),
+		"project": property.New(float64(env.project)),
+	}
+	if i.PTRRecord != "" {
+		m["ptrRecord"] = property.New(i.PTRRecord)
+	}
+	if i.ARecord != "" {
+		m["aRecord"] = property.New(i.ARecord)
+	}
+	if i.Tags != nil {
+		tags := map[string]property.Value{}
+		for k, v := range i.Tags {
+			tags[k] = property.New(v)
+		}
+		m["tags"] = property.New(tags)
+	}
+	switch i.Assignment {
+	case routed:
+		m["routedTo"] = property.New(env.routeTo)
+	case targeted:
+		m["targetedTo"] = property.New(float64(env.server))
+	}
+	if i.DeletionProtection {
+		m["deletionProtection"] = property.New(true)
+	}
+	if i.RetainOnDelete {
+		m["retainOnDelete"] = property.New(true)
+	}
+	return property.NewMap(m)
+}
+
+func TestIPRoundTrip(t *testing.T) {
+	server := newRoundTripServer(t)
+
+	api := newFakeAPI(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+	env := ipEnv{
+		project: project,
+		region:  "LT-Siauliai",
+		routeTo: api.AddIP(project, "LT-Siauliai"),
+		server:  api.AddServer(project, "LT-Siauliai", "B1-1-1gb-20s-shared"),
+	}
+
+	// Cleanups run last to first, so this runs after the round trips' deletes.
+	existing := len(api.IPAddresses())
+	t.Cleanup(func() {
+		assert.Len(t, api.IPAddresses(), existing, "only the addresses of the environment must be left")
+	})
+
+	holds := func(create, update ipInputs) bool {
+		err := checkRoundTrip(t, server, urn("network:IP", "test"), create.toMap(env), update.toMap(env))
+		if err != nil {
+			t.Log(err)
+		}
+		return err == nil
+	}
+
+	if err := quick.Check(holds, roundTripConfig(t)); err != nil {
+		t.Error(err)
+	}
+}
+
+// projectInputs are random inputs for a project, with the team left to the test.
+type projectInputs struct {
+	Name               string
+	BGP                bool
+	DeletionProtection bool
+	ForceDestroy       bool
+}
+
+func (projectInputs) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(projectInputs{
+		Name: maybe(r, func(r *rand.Rand) string {
+			return fmt.Sprintf("project-%d", r.Intn(1000))
+		}),
+		BGP:                r.Intn(2) == 0,
+		DeletionProtection: r.Intn(2) == 0,
+		ForceDestroy:       r.Intn(2) == 0,
+	})
+}
+
+func (i projectInputs) toMap(team int) property.Map {
+	m := map[string]property.Value{
+		"team": property.New(float64(team)),
+	}
+	if i.Name != "" {
+		m["name"] = property.New(i.Name)
+	}
+	if i.BGP {
+		m["bgp"] = property.New(true)
+	}
+	if i.DeletionProtection {
+		m["deletionProtection"] = property.New(true)
+	}
+	if i.ForceDestroy {
+		m["forceDestroy"] = property.New(true)
+	}
+	return property.NewMap(m)
+}
+
+func TestProjectRoundTrip(t *testing.T) {
+	server := newRoundTripServer(t)
+
+	api := newFakeAPI(t)
+	team := api.AddTeam("test")
+
+	// Cleanups run last to first, so this runs after the round trips' deletes.
+	t.Cleanup(func() {
+		assert.Empty(t, api.Projects())
+	})
+
+	holds := func(create, update projectInputs) bool {
+		err := checkRoundTrip(t, server, urn("index:Project", "test"), create.toMap(team), update.toMap(team))
+		if err != nil {
+			t.Log(err)
+		}
+		return err == nil
+	}
+
+	if err := quick.Check(holds, roundTripConfig(t)); err != nil {
+		t.Error(err)
+	}
+}
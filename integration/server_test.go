@@ -1,6 +1,7 @@
 package integration_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/blang/semver"
@@ -11,13 +12,21 @@ import (
 func newServer(t *testing.T) integration.Server {
 	t.Helper()
 
+	return newServerContext(t.Context(), t)
+}
+
+// newServerContext is like newServer, but the server runs in ctx,
+// e.g. one that isn't cancelled before the test's cleanups run.
+func newServerContext(ctx context.Context, t *testing.T) integration.Server {
+	t.Helper()
+
 	prov, err := provider.Provider()
 	if err != nil {
 		t.Fatalf("failed to build provider: %v", err)
 	}
 
 	server, err := integration.NewServer(
-		t.Context(),
+		ctx,
 		provider.Name,
 		semver.MustParse("1.0.0"),
 		integration.WithProvider(prov),
@@ -0,0 +1,123 @@
+package integration_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordSpans records the spans ended during the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+// findSpan returns the ended span with the name.
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	var names []string
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+		names = append(names, span.Name())
+	}
+
+	t.Fatalf("no span named %q, only %q", name, names)
+	return nil
+}
+
+func TestResourceOperationSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	server := newServer(t)
+
+	_, err := server.Create(p.CreateRequest{
+		Urn: urn("network:IP", "ip"),
+		Properties: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(1.0),
+		}),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "pulumi-cherry-servers:network:IP Create", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(),
+		attribute.String("cherry.resource.type", "pulumi-cherry-servers:network:IP"))
+	assert.Contains(t, spans[0].Attributes(), attribute.Bool("cherry.dry_run", true))
+}
+
+func TestAPICallSpans(t *testing.T) {
+	recorder := recordSpans(t)
+	server := newServer(t)
+
+	api := newFakeAPI(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+
+	_, err := server.Create(p.CreateRequest{
+		Urn: urn("network:IP", "ip"),
+		Properties: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(float64(project)),
+		}),
+	})
+	require.NoError(t, err)
+
+	operation := findSpan(t, recorder, "pulumi-cherry-servers:network:IP Create")
+	call := findSpan(t, recorder, "POST /v1/projects/"+strconv.Itoa(project)+"/ips")
+
+	assert.Equal(t, trace.SpanKindClient, call.SpanKind())
+	assert.Equal(t, operation.SpanContext().SpanID(), call.Parent().SpanID(),
+		"API calls must be children of the resource operation")
+	assert.Contains(t, call.Attributes(), semconv.HTTPRequestMethodKey.String(http.MethodPost))
+	assert.Contains(t, call.Attributes(), semconv.HTTPResponseStatusCode(http.StatusCreated))
+	assert.Contains(t, call.Attributes(), attribute.Int("cherry.retry_count", 0))
+}
+
+func TestAPICallSpanRetries(t *testing.T) {
+	recorder := recordSpans(t)
+	server := newServer(t)
+
+	api := newFakeAPI(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+	id := api.AddIP(project, "LT-Siauliai")
+	api.Fail(http.MethodGet, "/v1/ips/"+id, http.StatusServiceUnavailable, 1)
+
+	resp, err := server.Read(p.ReadRequest{
+		ID:  id,
+		Urn: urn("network:IP", "ip"),
+		Properties: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(float64(project)),
+		}),
+	})
+	require.NoError(t, err)
+	require.Equal(t, id, resp.ID)
+
+	call := findSpan(t, recorder, "GET /v1/ips/"+id)
+
+	assert.Contains(t, call.Attributes(), semconv.HTTPRequestMethodKey.String(http.MethodGet))
+	assert.Contains(t, call.Attributes(), semconv.HTTPResponseStatusCode(http.StatusOK),
+		"the span records the outcome of the last attempt")
+	assert.Contains(t, call.Attributes(), attribute.Int("cherry.retry_count", 1))
+}
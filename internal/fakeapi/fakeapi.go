@@ -0,0 +1,247 @@
+// Package fakeapi is an in-memory fake of the Cherry Servers API, for tests that can't use the real one.
+//
+// It keeps the state of teams, projects, IP addresses, servers, regions and plans,
+// and serves the subset of the API that the provider uses, with the same JSON shapes,
+// so that cherrygo can be pointed at it. Changes take effect right away:
+// servers are active and IP addresses are assigned as soon as the request returns.
+// Projects never have volumes. Fail makes requests fail, e.g. to test retries.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+// Server is a fake Cherry Servers API, listening on a local port.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	nextID   int
+	teams    map[int]cherrygo.Team
+	projects map[int]project
+	ips      map[string]cherrygo.IPAddress
+	servers  map[int]cherrygo.Server
+	volumes  map[int]volume
+	regions  []cherrygo.Region
+	plans    []cherrygo.Plan
+	failures []failure
+}
+
+// failure is an error response to the next requests with a method and path.
+type failure struct {
+	method, path string
+	status       int
+	remaining    int
+}
+
+type project struct {
+	cherrygo.Project
+
+	team int
+}
+
+// DefaultRegions are the regions offered by a new Server.
+//
+//nolint:mnd // Fixture IDs.
+func DefaultRegions() []cherrygo.Region {
+	return []cherrygo.Region{
+		{ID: 1, Name: "Lithuania", Slug: "LT-Siauliai", RegionIso2: "LT", Location: "Siauliai"},
+		{ID: 2, Name: "Netherlands", Slug: "NL-Amsterdam", RegionIso2: "NL", Location: "Amsterdam"},
+		{ID: 3, Name: "United States", Slug: "US-Chicago", RegionIso2: "US", Location: "Chicago"},
+	}
+}
+
+// DefaultPlans are the plans offered by a new Server.
+//
+//nolint:mnd // Fixture IDs.
+func DefaultPlans() []cherrygo.Plan {
+	return []cherrygo.Plan{
+		{ID: 1, Name: "B1-1-1gb-20s-shared", Slug: "B1-1-1gb-20s-shared", Type: "vps", Category: "shared"},
+		{ID: 2, Name: "E3-1240v3", Slug: "e3_1240v3", Type: "baremetal", Category: "dedicated"},
+	}
+}
+
+// New starts a fake API with the default regions and plans, and no teams.
+// It has to be closed when the test is done.
+func New() *Server {
+	s := &Server{
+		nextID:   1,
+		teams:    map[int]cherrygo.Team{},
+		projects: map[int]project{},
+		ips:      map[string]cherrygo.IPAddress{},
+		servers:  map[int]cherrygo.Server{},
+		volumes:  map[int]volume{},
+		regions:  DefaultRegions(),
+		plans:    DefaultPlans(),
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// AddTeam creates a team and returns its ID.
+func (s *Server) AddTeam(name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	s.teams[id] = cherrygo.Team{ID: id, Name: name, Href: fmt.Sprintf("/teams/%d", id)}
+	return id
+}
+
+// Projects returns the projects of all teams.
+func (s *Server) Projects() []cherrygo.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projects := make([]cherrygo.Project, 0, len(s.projects))
+	for _, id := range slices.Sorted(maps.Keys(s.projects)) {
+		projects = append(projects, s.projects[id].Project)
+	}
+	return projects
+}
+
+// IPAddresses returns the IP addresses of all projects.
+func (s *Server) IPAddresses() []cherrygo.IPAddress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Collect(s.sortedIPs(func(cherrygo.IPAddress) bool { return true }))
+}
+
+// Servers returns the servers of all projects.
+func (s *Server) Servers() []cherrygo.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	servers := make([]cherrygo.Server, 0, len(s.servers))
+	for _, id := range slices.Sorted(maps.Keys(s.servers)) {
+		servers = append(servers, s.servers[id])
+	}
+	return servers
+}
+
+// Fail makes the next count requests with method to path fail with status, without handling them.
+func (s *Server) Fail(method, path string, status, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{method: method, path: path, status: status, remaining: count})
+}
+
+// injectedFailure returns the status to fail r with, if any.
+func (s *Server) injectedFailure(r *http.Request) (int, bool) {
+	for i := range s.failures {
+		f := &s.failures[i]
+		if f.remaining > 0 && f.method == r.Method && f.path == r.URL.Path {
+			f.remaining--
+			return f.status, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) newID() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/teams", s.listTeams)
+	mux.HandleFunc("GET /v1/teams/{team}/projects", s.listProjects)
+	mux.HandleFunc("POST /v1/teams/{team}/projects", s.createProject)
+	mux.HandleFunc("GET /v1/teams/{team}/plans", s.listPlans)
+	mux.HandleFunc("GET /v1/projects/{project}", s.getProject)
+	mux.HandleFunc("PUT /v1/projects/{project}", s.updateProject)
+	mux.HandleFunc("DELETE /v1/projects/{project}", s.deleteProject)
+
+	mux.HandleFunc("GET /v1/projects/{project}/ips", s.listIPs)
+	mux.HandleFunc("POST /v1/projects/{project}/ips", s.createIP)
+	mux.HandleFunc("GET /v1/ips/{ip}", s.getIP)
+	mux.HandleFunc("PUT /v1/ips/{ip}", s.updateIP)
+	mux.HandleFunc("DELETE /v1/ips/{ip}", s.deleteIP)
+
+	mux.HandleFunc("GET /v1/projects/{project}/servers", s.listServers)
+	mux.HandleFunc("POST /v1/projects/{project}/servers", s.createServer)
+	mux.HandleFunc("GET /v1/servers/{server}", s.getServer)
+	mux.HandleFunc("PUT /v1/servers/{server}", s.updateServer)
+	mux.HandleFunc("DELETE /v1/servers/{server}", s.deleteServer)
+
+	mux.HandleFunc("GET /v1/projects/{project}/storages", s.listStorages)
+	mux.HandleFunc("DELETE /v1/storages/{storage}/attachments", s.detachStorage)
+	mux.HandleFunc("DELETE /v1/storages/{storage}", s.deleteStorage)
+
+	mux.HandleFunc("GET /v1/regions", s.listRegions)
+	mux.HandleFunc("GET /v1/regions/{region}", s.getRegion)
+	mux.HandleFunc("GET /v1/plans", s.listPlans)
+	mux.HandleFunc("GET /v1/plans/{plan}", s.getPlan)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); !ok || token == "" {
+			writeErrorf(w, http.StatusUnauthorized, "missing API token")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if status, ok := s.injectedFailure(r); ok {
+			writeErrorf(w, status, "injected failure")
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeErrorf responds in the shape of API errors, which cherrygo turns into error messages.
+func writeErrorf(w http.ResponseWriter, status int, format string, a ...any) {
+	writeJSON(w, status, map[string]any{"code": status, "message": fmt.Sprintf(format, a...)})
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeErrorf(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+// page returns the page of items selected by the limit and offset query parameters,
+// or all of them if there's no limit.
+func page[T any](r *http.Request, items []T) []T {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	items = items[min(max(offset, 0), len(items)):]
+	if limit, _ := strconv.Atoi(r.URL.Query().Get("limit")); limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// intPathValue parses a numeric ID from the path, responding with not found if it isn't one.
+func intPathValue(w http.ResponseWriter, r *http.Request, name string) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		writeErrorf(w, http.StatusNotFound, "%s %q not found", name, r.PathValue(name))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,272 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+// Types of IP addresses.
+const (
+	FloatingIP = "floating-ip"
+	PrimaryIP  = "primary-ip"
+)
+
+func (s *Server) sortedIPs(keep func(cherrygo.IPAddress) bool) iter.Seq[cherrygo.IPAddress] {
+	return func(yield func(cherrygo.IPAddress) bool) {
+		for _, id := range slices.Sorted(maps.Keys(s.ips)) {
+			if ip := s.ips[id]; keep(ip) && !yield(ip) {
+				return
+			}
+		}
+	}
+}
+
+// Addresses are allocated from 5.199.171.0 onwards, 250 per /24.
+const (
+	firstSubnet  = 171
+	ipsPerSubnet = 250
+	ipv4         = 4
+)
+
+// newIP allocates an address in the project.
+func (s *Server) newIP(p cherrygo.Project, region cherrygo.Region, typ string) cherrygo.IPAddress {
+	n := s.newID()
+	id := fmt.Sprintf("00000000-0000-4000-8000-%012d", n)
+	address := fmt.Sprintf("5.199.%d.%d", firstSubnet+n/ipsPerSubnet, 1+n%ipsPerSubnet)
+	tags := map[string]string{}
+
+	return cherrygo.IPAddress{
+		ID:            id,
+		Address:       address,
+		AddressFamily: ipv4,
+		Cidr:          address + "/32",
+		Gateway:       fmt.Sprintf("5.199.%d.254", firstSubnet+n/ipsPerSubnet),
+		Type:          typ,
+		Region:        region,
+		Project:       cherrygo.Project{ID: p.ID, Name: p.Name, Href: p.Href},
+		Tags:          &tags,
+		Href:          "/ips/" + id,
+	}
+}
+
+// AddIP allocates a floating IP address in the project and returns its ID.
+// It panics if the project or region doesn't exist.
+func (s *Server) AddIP(projectID int, region string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		panic(fmt.Sprintf("fakeapi: project %d not found", projectID))
+	}
+	r, ok := s.region(region)
+	if !ok {
+		panic(fmt.Sprintf("fakeapi: region %q not found", region))
+	}
+
+	ip := s.newIP(p.Project, r, FloatingIP)
+	s.ips[ip.ID] = ip
+	return ip.ID
+}
+
+func (s *Server) listIPs(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	ips := slices.Collect(s.sortedIPs(func(ip cherrygo.IPAddress) bool { return ip.Project.ID == p.ID }))
+	if ips == nil {
+		ips = []cherrygo.IPAddress{}
+	}
+	writeJSON(w, http.StatusOK, ips)
+}
+
+func (s *Server) createIP(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if !decode(w, r, &fields) {
+		return
+	}
+
+	var slug string
+	_ = json.Unmarshal(fields["region"], &slug)
+	region, ok := s.region(slug)
+	if !ok {
+		writeErrorf(w, http.StatusBadRequest, "region %q not found", slug)
+		return
+	}
+
+	ip := s.newIP(p.Project, region, FloatingIP)
+	if !s.applyIPFields(w, &ip, fields) {
+		return
+	}
+	s.ips[ip.ID] = ip
+
+	writeJSON(w, http.StatusCreated, ip)
+}
+
+func (s *Server) ip(w http.ResponseWriter, r *http.Request) (cherrygo.IPAddress, bool) {
+	ip, ok := s.ips[r.PathValue("ip")]
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "ip address %q not found", r.PathValue("ip"))
+	}
+	return ip, ok
+}
+
+func (s *Server) getIP(w http.ResponseWriter, r *http.Request) {
+	if ip, ok := s.ip(w, r); ok {
+		writeJSON(w, http.StatusOK, ip)
+	}
+}
+
+// updateIP changes the fields present in the request. Null clears records,
+// and a targeted_to of 0 unassigns the address.
+func (s *Server) updateIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := s.ip(w, r)
+	if !ok {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if !decode(w, r, &fields) {
+		return
+	}
+
+	if !s.applyIPFields(w, &ip, fields) {
+		return
+	}
+	s.ips[ip.ID] = ip
+
+	writeJSON(w, http.StatusOK, ip)
+}
+
+func (s *Server) deleteIP(w http.ResponseWriter, r *http.Request) {
+	ip, ok := s.ip(w, r)
+	if !ok {
+		return
+	}
+
+	if ip.Type != FloatingIP {
+		writeErrorf(w, http.StatusBadRequest, "ip address %s belongs to a server", ip.ID)
+		return
+	}
+
+	delete(s.ips, ip.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyIPFields applies the fields of a create or update request to ip.
+func (s *Server) applyIPFields(w http.ResponseWriter, ip *cherrygo.IPAddress, fields map[string]json.RawMessage) bool {
+	for key, raw := range fields {
+		var err error
+
+		switch key {
+		case "ptr_record":
+			ip.PtrRecord, err = nullableString(raw)
+		case "a_record":
+			ip.ARecord, err = nullableString(raw)
+		case "tags":
+			tags := map[string]string{}
+			if string(raw) != "null" {
+				err = json.Unmarshal(raw, &tags)
+			}
+			ip.Tags = &tags
+		case "routed_to":
+			err = s.routeIP(ip, raw)
+		case "targeted_to", "assigned_to":
+			err = s.targetIP(ip, raw)
+		}
+
+		if err != nil {
+			writeErrorf(w, http.StatusBadRequest, "invalid %s: %v", key, err)
+			return false
+		}
+	}
+	return true
+}
+
+// nullableString decodes a string field, where null clears it.
+func nullableString(raw json.RawMessage) (string, error) {
+	var str *string
+	if err := json.Unmarshal(raw, &str); err != nil || str == nil {
+		return "", err
+	}
+	return *str, nil
+}
+
+func (s *Server) routeIP(ip *cherrygo.IPAddress, raw json.RawMessage) error {
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil || id == "" {
+		return err
+	}
+
+	target, ok := s.ips[id]
+	if !ok || target.Project.ID != ip.Project.ID {
+		return fmt.Errorf("ip address %q not found in project %d", id, ip.Project.ID)
+	}
+
+	ip.RoutedTo = cherrygo.RoutedTo{
+		ID:            target.ID,
+		Address:       target.Address,
+		AddressFamily: target.AddressFamily,
+		Cidr:          target.Cidr,
+		Gateway:       target.Gateway,
+		Type:          target.Type,
+		Region:        target.Region,
+	}
+	ip.TargetedTo = cherrygo.AssignedTo{}
+	ip.AssignedTo = cherrygo.AssignedTo{}
+	return nil
+}
+
+func (s *Server) targetIP(ip *cherrygo.IPAddress, raw json.RawMessage) error {
+	var id int
+	if err := json.Unmarshal(raw, &id); err != nil {
+		// cherrygo sends server IDs as strings in some requests and as numbers in others.
+		var str string
+		if err = json.Unmarshal(raw, &str); err != nil {
+			return err
+		}
+		if str == "" {
+			return nil
+		}
+		if id, err = strconv.Atoi(str); err != nil {
+			return err
+		}
+	}
+
+	ip.RoutedTo = cherrygo.RoutedTo{}
+	ip.TargetedTo = cherrygo.AssignedTo{}
+	ip.AssignedTo = cherrygo.AssignedTo{}
+	if id == 0 {
+		return nil
+	}
+
+	server, ok := s.servers[id]
+	if !ok || server.Project.ID != ip.Project.ID {
+		return fmt.Errorf("server %d not found in project %d", id, ip.Project.ID)
+	}
+
+	ip.TargetedTo = cherrygo.AssignedTo{
+		ID:       server.ID,
+		Name:     server.Name,
+		Href:     server.Href,
+		Hostname: server.Hostname,
+		Region:   server.Region,
+		State:    server.State,
+	}
+	ip.AssignedTo = ip.TargetedTo
+	return nil
+}
@@ -0,0 +1,209 @@
+package fakeapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request) {
+	teams := make([]cherrygo.Team, 0, len(s.teams))
+	for _, id := range slices.Sorted(maps.Keys(s.teams)) {
+		teams = append(teams, s.teams[id])
+	}
+	writeJSON(w, http.StatusOK, page(r, teams))
+}
+
+// AddProject creates a project in the team and returns its ID.
+func (s *Server) AddProject(team int, name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	s.projects[id] = project{
+		Project: cherrygo.Project{ID: id, Name: name, Href: fmt.Sprintf("/projects/%d", id)},
+		team:    team,
+	}
+	return id
+}
+
+func (s *Server) team(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, ok := intPathValue(w, r, "team")
+	if !ok {
+		return 0, false
+	}
+
+	if _, ok = s.teams[id]; !ok {
+		writeErrorf(w, http.StatusNotFound, "team %d not found", id)
+		return 0, false
+	}
+	return id, true
+}
+
+func (s *Server) project(w http.ResponseWriter, r *http.Request) (project, bool) {
+	id, ok := intPathValue(w, r, "project")
+	if !ok {
+		return project{}, false
+	}
+
+	p, ok := s.projects[id]
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "project %d not found", id)
+		return project{}, false
+	}
+	return p, true
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	team, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	projects := []cherrygo.Project{}
+	for _, id := range slices.Sorted(maps.Keys(s.projects)) {
+		if p := s.projects[id]; p.team == team {
+			projects = append(projects, p.Project)
+		}
+	}
+	writeJSON(w, http.StatusOK, page(r, projects))
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	team, ok := s.team(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateProject
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Name == "" {
+		writeErrorf(w, http.StatusBadRequest, "project name is required")
+		return
+	}
+
+	id := s.newID()
+	p := project{
+		Project: cherrygo.Project{
+			ID:   id,
+			Name: req.Name,
+			Bgp:  cherrygo.ProjectBGP{Enabled: req.Bgp},
+			Href: fmt.Sprintf("/projects/%d", id),
+		},
+		team: team,
+	}
+	s.projects[id] = p
+
+	writeJSON(w, http.StatusCreated, p.Project)
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	if p, ok := s.project(w, r); ok {
+		writeJSON(w, http.StatusOK, p.Project)
+	}
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateProject
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Name != nil {
+		p.Name = *req.Name
+	}
+	if req.Bgp != nil {
+		p.Bgp.Enabled = *req.Bgp
+	}
+	s.projects[p.ID] = p
+
+	writeJSON(w, http.StatusOK, p.Project)
+}
+
+// deleteProject deletes the project and its IP addresses.
+// It refuses to delete projects that still have servers or volumes, so that leaked ones don't go unnoticed.
+func (s *Server) deleteProject(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	for _, server := range s.servers {
+		if server.Project.ID == p.ID {
+			writeErrorf(w, http.StatusConflict, "project %d still has servers", p.ID)
+			return
+		}
+	}
+
+	for _, v := range s.volumes {
+		if v.project == p.ID {
+			writeErrorf(w, http.StatusConflict, "project %d still has volumes", p.ID)
+			return
+		}
+	}
+
+	for id, ip := range s.ips {
+		if ip.Project.ID == p.ID {
+			delete(s.ips, id)
+		}
+	}
+	delete(s.projects, p.ID)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listRegions(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.regions)
+}
+
+func (s *Server) region(slug string) (cherrygo.Region, bool) {
+	for _, region := range s.regions {
+		if region.Slug == slug {
+			return region, true
+		}
+	}
+	return cherrygo.Region{}, false
+}
+
+func (s *Server) getRegion(w http.ResponseWriter, r *http.Request) {
+	region, ok := s.region(r.PathValue("region"))
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "region %q not found", r.PathValue("region"))
+		return
+	}
+	writeJSON(w, http.StatusOK, region)
+}
+
+func (s *Server) listPlans(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.plans)
+}
+
+func (s *Server) plan(slug string) (cherrygo.Plan, bool) {
+	for _, plan := range s.plans {
+		if plan.Slug == slug || strconv.Itoa(plan.ID) == slug {
+			return plan, true
+		}
+	}
+	return cherrygo.Plan{}, false
+}
+
+func (s *Server) getPlan(w http.ResponseWriter, r *http.Request) {
+	plan, ok := s.plan(r.PathValue("plan"))
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "plan %q not found", r.PathValue("plan"))
+		return
+	}
+	writeJSON(w, http.StatusOK, plan)
+}
@@ -0,0 +1,187 @@
+package fakeapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+func (s *Server) listServers(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	servers := []cherrygo.Server{}
+	for _, id := range slices.Sorted(maps.Keys(s.servers)) {
+		if server := s.servers[id]; server.Project.ID == p.ID {
+			servers = append(servers, server)
+		}
+	}
+	writeJSON(w, http.StatusOK, servers)
+}
+
+func (s *Server) createServer(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.CreateServer
+	if !decode(w, r, &req) {
+		return
+	}
+
+	plan, ok := s.plan(req.Plan)
+	if !ok {
+		writeErrorf(w, http.StatusBadRequest, "plan %q not found", req.Plan)
+		return
+	}
+
+	region, ok := s.region(req.Region)
+	if !ok {
+		writeErrorf(w, http.StatusBadRequest, "region %q not found", req.Region)
+		return
+	}
+
+	var tags map[string]string
+	if req.Tags != nil {
+		tags = maps.Clone(*req.Tags)
+	}
+
+	server := s.deployServer(p.Project, region, plan, req.Hostname, req.Image, tags)
+	writeJSON(w, http.StatusCreated, server)
+}
+
+// AddServer deploys a server in the project and returns its ID.
+// It panics if the project, region or plan doesn't exist.
+func (s *Server) AddServer(projectID int, region, plan string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.projects[projectID]
+	if !ok {
+		panic(fmt.Sprintf("fakeapi: project %d not found", projectID))
+	}
+	r, ok := s.region(region)
+	if !ok {
+		panic(fmt.Sprintf("fakeapi: region %q not found", region))
+	}
+	pl, ok := s.plan(plan)
+	if !ok {
+		panic(fmt.Sprintf("fakeapi: plan %q not found", plan))
+	}
+
+	return s.deployServer(p.Project, r, pl, "", "", nil).ID
+}
+
+// deployServer adds an active server, with a primary IP address.
+func (s *Server) deployServer(
+	p cherrygo.Project, region cherrygo.Region, plan cherrygo.Plan, hostname, image string, tags map[string]string,
+) cherrygo.Server {
+	id := s.newID()
+	if hostname == "" {
+		hostname = fmt.Sprintf("server-%d", id)
+	}
+
+	server := cherrygo.Server{
+		ID:       id,
+		Name:     plan.Name,
+		Href:     fmt.Sprintf("/servers/%d", id),
+		Hostname: hostname,
+		Image:    image,
+		Project:  cherrygo.Project{ID: p.ID, Name: p.Name, Href: p.Href},
+		Region:   region,
+		State:    "active",
+		Status:   "deployed",
+		Plan:     plan,
+		Tags:     tags,
+	}
+
+	primary := s.newIP(p, region, PrimaryIP)
+	primary.TargetedTo = cherrygo.AssignedTo{ID: id, Hostname: hostname, Href: server.Href, Region: region}
+	primary.AssignedTo = primary.TargetedTo
+	s.ips[primary.ID] = primary
+	server.IPAddresses = []cherrygo.IPAddress{primary}
+
+	s.servers[id] = server
+	return server
+}
+
+func (s *Server) server(w http.ResponseWriter, r *http.Request) (cherrygo.Server, bool) {
+	id, ok := intPathValue(w, r, "server")
+	if !ok {
+		return cherrygo.Server{}, false
+	}
+
+	server, ok := s.servers[id]
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "server %d not found", id)
+	}
+	return server, ok
+}
+
+func (s *Server) getServer(w http.ResponseWriter, r *http.Request) {
+	if server, ok := s.server(w, r); ok {
+		writeJSON(w, http.StatusOK, server)
+	}
+}
+
+func (s *Server) updateServer(w http.ResponseWriter, r *http.Request) {
+	server, ok := s.server(w, r)
+	if !ok {
+		return
+	}
+
+	var req cherrygo.UpdateServer
+	if !decode(w, r, &req) {
+		return
+	}
+
+	if req.Name != "" {
+		server.Name = req.Name
+	}
+	if req.Hostname != "" {
+		server.Hostname = req.Hostname
+	}
+	if req.Tags != nil {
+		server.Tags = maps.Clone(*req.Tags)
+	}
+	server.BGP.Enabled = req.Bgp
+	s.servers[server.ID] = server
+
+	writeJSON(w, http.StatusOK, server)
+}
+
+// deleteServer deletes the server with its primary IP address,
+// and unassigns the addresses that were targeted to it and detaches its volumes.
+func (s *Server) deleteServer(w http.ResponseWriter, r *http.Request) {
+	server, ok := s.server(w, r)
+	if !ok {
+		return
+	}
+
+	for id, ip := range s.ips {
+		switch {
+		case ip.Type == PrimaryIP && ip.TargetedTo.ID == server.ID:
+			delete(s.ips, id)
+		case ip.TargetedTo.ID == server.ID:
+			ip.TargetedTo = cherrygo.AssignedTo{}
+			ip.AssignedTo = cherrygo.AssignedTo{}
+			s.ips[id] = ip
+		}
+	}
+	for id, v := range s.volumes {
+		if v.AttachedTo.ID == server.ID {
+			v.AttachedTo = cherrygo.AttachedTo{}
+			s.volumes[id] = v
+		}
+	}
+	delete(s.servers, server.ID)
+
+	server.State = "terminating"
+	writeJSON(w, http.StatusOK, server)
+}
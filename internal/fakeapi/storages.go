@@ -0,0 +1,111 @@
+package fakeapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+// volume is a block storage volume, which the API doesn't report the project of.
+type volume struct {
+	cherrygo.BlockStorage
+
+	project int
+}
+
+// AddVolume creates a volume in the project, attached to the server unless it's 0, and returns its ID.
+// It panics if the project or server doesn't exist.
+func (s *Server) AddVolume(projectID, serverID int, name string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.projects[projectID]; !ok {
+		panic(fmt.Sprintf("fakeapi: project %d not found", projectID))
+	}
+
+	id := s.newID()
+	v := volume{
+		BlockStorage: cherrygo.BlockStorage{ID: id, Name: name, Href: fmt.Sprintf("/storages/%d", id)},
+		project:      projectID,
+	}
+	if serverID != 0 {
+		server, ok := s.servers[serverID]
+		if !ok {
+			panic(fmt.Sprintf("fakeapi: server %d not found", serverID))
+		}
+		v.AttachedTo = cherrygo.AttachedTo{ID: server.ID, Hostname: server.Hostname, Href: server.Href}
+		v.Region = server.Region
+	}
+	s.volumes[id] = v
+	return id
+}
+
+// Volumes returns the volumes of all projects.
+func (s *Server) Volumes() []cherrygo.BlockStorage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	volumes := make([]cherrygo.BlockStorage, 0, len(s.volumes))
+	for _, id := range slices.Sorted(maps.Keys(s.volumes)) {
+		volumes = append(volumes, s.volumes[id].BlockStorage)
+	}
+	return volumes
+}
+
+func (s *Server) listStorages(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.project(w, r)
+	if !ok {
+		return
+	}
+
+	volumes := []cherrygo.BlockStorage{}
+	for _, id := range slices.Sorted(maps.Keys(s.volumes)) {
+		if v := s.volumes[id]; v.project == p.ID {
+			volumes = append(volumes, v.BlockStorage)
+		}
+	}
+	writeJSON(w, http.StatusOK, volumes)
+}
+
+func (s *Server) volume(w http.ResponseWriter, r *http.Request) (volume, bool) {
+	id, ok := intPathValue(w, r, "storage")
+	if !ok {
+		return volume{}, false
+	}
+
+	v, ok := s.volumes[id]
+	if !ok {
+		writeErrorf(w, http.StatusNotFound, "storage %d not found", id)
+	}
+	return v, ok
+}
+
+func (s *Server) detachStorage(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.volume(w, r)
+	if !ok {
+		return
+	}
+
+	v.AttachedTo = cherrygo.AttachedTo{}
+	s.volumes[v.ID] = v
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteStorage refuses to delete attached volumes, which have to be detached first.
+func (s *Server) deleteStorage(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.volume(w, r)
+	if !ok {
+		return
+	}
+
+	if v.AttachedTo.ID != 0 {
+		writeErrorf(w, http.StatusConflict, "storage %d is attached to server %d", v.ID, v.AttachedTo.ID)
+		return
+	}
+
+	delete(s.volumes, v.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,720 @@
+// Code generated by go run ./gen; DO NOT EDIT.
+
+package fakeclient
+
+import (
+	"context"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+// IPClient is a fake provider.IPClient.
+type IPClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.IPClient
+
+	AssignFunc       func(ipID string, request *cherrygo.AssignIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error)
+	ClearRecordsFunc func(ipID string, request *provider.ClearIPRecords) (cherrygo.IPAddress, *cherrygo.Response, error)
+	CreateFunc       func(projectID int, request *cherrygo.CreateIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error)
+	GetFunc          func(ipID string, opts *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error)
+	ListFunc         func(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.IPAddress, *cherrygo.Response, error)
+	RemoveFunc       func(ipID string) (*cherrygo.Response, error)
+	UnassignFunc     func(ipID string) (*cherrygo.Response, error)
+	UpdateFunc       func(ipID string, request *cherrygo.UpdateIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error)
+}
+
+var _ provider.IPClient = (*IPClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *IPClient) Factory() provider.IPClientFactory {
+	return func(context.Context) (provider.IPClient, error) {
+		return c, nil
+	}
+}
+
+// Assign calls AssignFunc, or Default.Assign if it's nil.
+func (c *IPClient) Assign(ipID string, request *cherrygo.AssignIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("Assign", ipID, request)
+
+	if c.AssignFunc != nil {
+		return c.AssignFunc(ipID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Assign"))
+	}
+	return c.Default.Assign(ipID, request)
+}
+
+// ClearRecords calls ClearRecordsFunc, or Default.ClearRecords if it's nil.
+func (c *IPClient) ClearRecords(ipID string, request *provider.ClearIPRecords) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("ClearRecords", ipID, request)
+
+	if c.ClearRecordsFunc != nil {
+		return c.ClearRecordsFunc(ipID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "ClearRecords"))
+	}
+	return c.Default.ClearRecords(ipID, request)
+}
+
+// Create calls CreateFunc, or Default.Create if it's nil.
+func (c *IPClient) Create(projectID int, request *cherrygo.CreateIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("Create", projectID, request)
+
+	if c.CreateFunc != nil {
+		return c.CreateFunc(projectID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Create"))
+	}
+	return c.Default.Create(projectID, request)
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *IPClient) Get(ipID string, opts *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("Get", ipID, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(ipID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Get"))
+	}
+	return c.Default.Get(ipID, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *IPClient) List(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("List", projectID, opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(projectID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "List"))
+	}
+	return c.Default.List(projectID, opts)
+}
+
+// Remove calls RemoveFunc, or Default.Remove if it's nil.
+func (c *IPClient) Remove(ipID string) (*cherrygo.Response, error) {
+	c.record("Remove", ipID)
+
+	if c.RemoveFunc != nil {
+		return c.RemoveFunc(ipID)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Remove"))
+	}
+	return c.Default.Remove(ipID)
+}
+
+// Unassign calls UnassignFunc, or Default.Unassign if it's nil.
+func (c *IPClient) Unassign(ipID string) (*cherrygo.Response, error) {
+	c.record("Unassign", ipID)
+
+	if c.UnassignFunc != nil {
+		return c.UnassignFunc(ipID)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Unassign"))
+	}
+	return c.Default.Unassign(ipID)
+}
+
+// Update calls UpdateFunc, or Default.Update if it's nil.
+func (c *IPClient) Update(ipID string, request *cherrygo.UpdateIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error) {
+	c.record("Update", ipID, request)
+
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(ipID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("IPClient", "Update"))
+	}
+	return c.Default.Update(ipID, request)
+}
+
+// ProjectClient is a fake provider.ProjectClient.
+type ProjectClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.ProjectClient
+
+	CreateFunc      func(teamID int, request *cherrygo.CreateProject) (cherrygo.Project, *cherrygo.Response, error)
+	DeleteFunc      func(projectID int) (*cherrygo.Response, error)
+	GetFunc         func(projectID int, opts *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error)
+	ListFunc        func(teamID int, opts *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error)
+	ListSSHKeysFunc func(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error)
+	UpdateFunc      func(projectID int, request *cherrygo.UpdateProject) (cherrygo.Project, *cherrygo.Response, error)
+}
+
+var _ provider.ProjectClient = (*ProjectClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *ProjectClient) Factory() provider.ProjectClientFactory {
+	return func(context.Context) (provider.ProjectClient, error) {
+		return c, nil
+	}
+}
+
+// Create calls CreateFunc, or Default.Create if it's nil.
+func (c *ProjectClient) Create(teamID int, request *cherrygo.CreateProject) (cherrygo.Project, *cherrygo.Response, error) {
+	c.record("Create", teamID, request)
+
+	if c.CreateFunc != nil {
+		return c.CreateFunc(teamID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "Create"))
+	}
+	return c.Default.Create(teamID, request)
+}
+
+// Delete calls DeleteFunc, or Default.Delete if it's nil.
+func (c *ProjectClient) Delete(projectID int) (*cherrygo.Response, error) {
+	c.record("Delete", projectID)
+
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(projectID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "Delete"))
+	}
+	return c.Default.Delete(projectID)
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *ProjectClient) Get(projectID int, opts *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+	c.record("Get", projectID, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(projectID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "Get"))
+	}
+	return c.Default.Get(projectID, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *ProjectClient) List(teamID int, opts *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+	c.record("List", teamID, opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(teamID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "List"))
+	}
+	return c.Default.List(teamID, opts)
+}
+
+// ListSSHKeys calls ListSSHKeysFunc, or Default.ListSSHKeys if it's nil.
+func (c *ProjectClient) ListSSHKeys(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+	c.record("ListSSHKeys", projectID, opts)
+
+	if c.ListSSHKeysFunc != nil {
+		return c.ListSSHKeysFunc(projectID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "ListSSHKeys"))
+	}
+	return c.Default.ListSSHKeys(projectID, opts)
+}
+
+// Update calls UpdateFunc, or Default.Update if it's nil.
+func (c *ProjectClient) Update(projectID int, request *cherrygo.UpdateProject) (cherrygo.Project, *cherrygo.Response, error) {
+	c.record("Update", projectID, request)
+
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(projectID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("ProjectClient", "Update"))
+	}
+	return c.Default.Update(projectID, request)
+}
+
+// RegionClient is a fake provider.RegionClient.
+type RegionClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.RegionClient
+
+	GetFunc  func(region string, opts *cherrygo.GetOptions) (cherrygo.Region, *cherrygo.Response, error)
+	ListFunc func(opts *cherrygo.GetOptions) ([]cherrygo.Region, *cherrygo.Response, error)
+}
+
+var _ provider.RegionClient = (*RegionClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *RegionClient) Factory() provider.RegionClientFactory {
+	return func(context.Context) (provider.RegionClient, error) {
+		return c, nil
+	}
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *RegionClient) Get(region string, opts *cherrygo.GetOptions) (cherrygo.Region, *cherrygo.Response, error) {
+	c.record("Get", region, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(region, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("RegionClient", "Get"))
+	}
+	return c.Default.Get(region, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *RegionClient) List(opts *cherrygo.GetOptions) ([]cherrygo.Region, *cherrygo.Response, error) {
+	c.record("List", opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("RegionClient", "List"))
+	}
+	return c.Default.List(opts)
+}
+
+// ServerClient is a fake provider.ServerClient.
+type ServerClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.ServerClient
+
+	CreateFunc           func(request *cherrygo.CreateServer) (cherrygo.Server, *cherrygo.Response, error)
+	DeleteFunc           func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	EnterRescueModeFunc  func(serverID int, fields *cherrygo.RescueServerFields) (cherrygo.Server, *cherrygo.Response, error)
+	ExitRescueModeFunc   func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	GetFunc              func(serverID int, opts *cherrygo.GetOptions) (cherrygo.Server, *cherrygo.Response, error)
+	ListFunc             func(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.Server, *cherrygo.Response, error)
+	ListCyclesFunc       func(opts *cherrygo.GetOptions) ([]cherrygo.ServerCycle, *cherrygo.Response, error)
+	ListSSHKeysFunc      func(serverID int, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error)
+	PowerOffFunc         func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	PowerOnFunc          func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	PowerStateFunc       func(serverID int) (cherrygo.PowerState, *cherrygo.Response, error)
+	RebootFunc           func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	ReinstallFunc        func(serverID int, fields *cherrygo.ReinstallServerFields) (cherrygo.Server, *cherrygo.Response, error)
+	ResetBMCPasswordFunc func(serverID int) (cherrygo.Server, *cherrygo.Response, error)
+	UpdateFunc           func(serverID int, request *cherrygo.UpdateServer) (cherrygo.Server, *cherrygo.Response, error)
+}
+
+var _ provider.ServerClient = (*ServerClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *ServerClient) Factory() provider.ServerClientFactory {
+	return func(context.Context) (provider.ServerClient, error) {
+		return c, nil
+	}
+}
+
+// Create calls CreateFunc, or Default.Create if it's nil.
+func (c *ServerClient) Create(request *cherrygo.CreateServer) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Create", request)
+
+	if c.CreateFunc != nil {
+		return c.CreateFunc(request)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Create"))
+	}
+	return c.Default.Create(request)
+}
+
+// Delete calls DeleteFunc, or Default.Delete if it's nil.
+func (c *ServerClient) Delete(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Delete", serverID)
+
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Delete"))
+	}
+	return c.Default.Delete(serverID)
+}
+
+// EnterRescueMode calls EnterRescueModeFunc, or Default.EnterRescueMode if it's nil.
+func (c *ServerClient) EnterRescueMode(serverID int, fields *cherrygo.RescueServerFields) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("EnterRescueMode", serverID, fields)
+
+	if c.EnterRescueModeFunc != nil {
+		return c.EnterRescueModeFunc(serverID, fields)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "EnterRescueMode"))
+	}
+	return c.Default.EnterRescueMode(serverID, fields)
+}
+
+// ExitRescueMode calls ExitRescueModeFunc, or Default.ExitRescueMode if it's nil.
+func (c *ServerClient) ExitRescueMode(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("ExitRescueMode", serverID)
+
+	if c.ExitRescueModeFunc != nil {
+		return c.ExitRescueModeFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "ExitRescueMode"))
+	}
+	return c.Default.ExitRescueMode(serverID)
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *ServerClient) Get(serverID int, opts *cherrygo.GetOptions) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Get", serverID, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(serverID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Get"))
+	}
+	return c.Default.Get(serverID, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *ServerClient) List(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.Server, *cherrygo.Response, error) {
+	c.record("List", projectID, opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(projectID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "List"))
+	}
+	return c.Default.List(projectID, opts)
+}
+
+// ListCycles calls ListCyclesFunc, or Default.ListCycles if it's nil.
+func (c *ServerClient) ListCycles(opts *cherrygo.GetOptions) ([]cherrygo.ServerCycle, *cherrygo.Response, error) {
+	c.record("ListCycles", opts)
+
+	if c.ListCyclesFunc != nil {
+		return c.ListCyclesFunc(opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "ListCycles"))
+	}
+	return c.Default.ListCycles(opts)
+}
+
+// ListSSHKeys calls ListSSHKeysFunc, or Default.ListSSHKeys if it's nil.
+func (c *ServerClient) ListSSHKeys(serverID int, opts *cherrygo.GetOptions) ([]cherrygo.SSHKey, *cherrygo.Response, error) {
+	c.record("ListSSHKeys", serverID, opts)
+
+	if c.ListSSHKeysFunc != nil {
+		return c.ListSSHKeysFunc(serverID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "ListSSHKeys"))
+	}
+	return c.Default.ListSSHKeys(serverID, opts)
+}
+
+// PowerOff calls PowerOffFunc, or Default.PowerOff if it's nil.
+func (c *ServerClient) PowerOff(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("PowerOff", serverID)
+
+	if c.PowerOffFunc != nil {
+		return c.PowerOffFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "PowerOff"))
+	}
+	return c.Default.PowerOff(serverID)
+}
+
+// PowerOn calls PowerOnFunc, or Default.PowerOn if it's nil.
+func (c *ServerClient) PowerOn(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("PowerOn", serverID)
+
+	if c.PowerOnFunc != nil {
+		return c.PowerOnFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "PowerOn"))
+	}
+	return c.Default.PowerOn(serverID)
+}
+
+// PowerState calls PowerStateFunc, or Default.PowerState if it's nil.
+func (c *ServerClient) PowerState(serverID int) (cherrygo.PowerState, *cherrygo.Response, error) {
+	c.record("PowerState", serverID)
+
+	if c.PowerStateFunc != nil {
+		return c.PowerStateFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "PowerState"))
+	}
+	return c.Default.PowerState(serverID)
+}
+
+// Reboot calls RebootFunc, or Default.Reboot if it's nil.
+func (c *ServerClient) Reboot(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Reboot", serverID)
+
+	if c.RebootFunc != nil {
+		return c.RebootFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Reboot"))
+	}
+	return c.Default.Reboot(serverID)
+}
+
+// Reinstall calls ReinstallFunc, or Default.Reinstall if it's nil.
+func (c *ServerClient) Reinstall(serverID int, fields *cherrygo.ReinstallServerFields) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Reinstall", serverID, fields)
+
+	if c.ReinstallFunc != nil {
+		return c.ReinstallFunc(serverID, fields)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Reinstall"))
+	}
+	return c.Default.Reinstall(serverID, fields)
+}
+
+// ResetBMCPassword calls ResetBMCPasswordFunc, or Default.ResetBMCPassword if it's nil.
+func (c *ServerClient) ResetBMCPassword(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("ResetBMCPassword", serverID)
+
+	if c.ResetBMCPasswordFunc != nil {
+		return c.ResetBMCPasswordFunc(serverID)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "ResetBMCPassword"))
+	}
+	return c.Default.ResetBMCPassword(serverID)
+}
+
+// Update calls UpdateFunc, or Default.Update if it's nil.
+func (c *ServerClient) Update(serverID int, request *cherrygo.UpdateServer) (cherrygo.Server, *cherrygo.Response, error) {
+	c.record("Update", serverID, request)
+
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(serverID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("ServerClient", "Update"))
+	}
+	return c.Default.Update(serverID, request)
+}
+
+// StorageClient is a fake provider.StorageClient.
+type StorageClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.StorageClient
+
+	AttachFunc func(request *cherrygo.AttachTo) (cherrygo.BlockStorage, *cherrygo.Response, error)
+	CreateFunc func(request *cherrygo.CreateStorage) (cherrygo.BlockStorage, *cherrygo.Response, error)
+	DeleteFunc func(storageID int) (*cherrygo.Response, error)
+	DetachFunc func(storageID int) (*cherrygo.Response, error)
+	GetFunc    func(storageID int, opts *cherrygo.GetOptions) (cherrygo.BlockStorage, *cherrygo.Response, error)
+	ListFunc   func(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.BlockStorage, *cherrygo.Response, error)
+	UpdateFunc func(request *cherrygo.UpdateStorage) (cherrygo.BlockStorage, *cherrygo.Response, error)
+}
+
+var _ provider.StorageClient = (*StorageClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *StorageClient) Factory() provider.StorageClientFactory {
+	return func(context.Context) (provider.StorageClient, error) {
+		return c, nil
+	}
+}
+
+// Attach calls AttachFunc, or Default.Attach if it's nil.
+func (c *StorageClient) Attach(request *cherrygo.AttachTo) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	c.record("Attach", request)
+
+	if c.AttachFunc != nil {
+		return c.AttachFunc(request)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Attach"))
+	}
+	return c.Default.Attach(request)
+}
+
+// Create calls CreateFunc, or Default.Create if it's nil.
+func (c *StorageClient) Create(request *cherrygo.CreateStorage) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	c.record("Create", request)
+
+	if c.CreateFunc != nil {
+		return c.CreateFunc(request)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Create"))
+	}
+	return c.Default.Create(request)
+}
+
+// Delete calls DeleteFunc, or Default.Delete if it's nil.
+func (c *StorageClient) Delete(storageID int) (*cherrygo.Response, error) {
+	c.record("Delete", storageID)
+
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(storageID)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Delete"))
+	}
+	return c.Default.Delete(storageID)
+}
+
+// Detach calls DetachFunc, or Default.Detach if it's nil.
+func (c *StorageClient) Detach(storageID int) (*cherrygo.Response, error) {
+	c.record("Detach", storageID)
+
+	if c.DetachFunc != nil {
+		return c.DetachFunc(storageID)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Detach"))
+	}
+	return c.Default.Detach(storageID)
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *StorageClient) Get(storageID int, opts *cherrygo.GetOptions) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	c.record("Get", storageID, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(storageID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Get"))
+	}
+	return c.Default.Get(storageID, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *StorageClient) List(projectID int, opts *cherrygo.GetOptions) ([]cherrygo.BlockStorage, *cherrygo.Response, error) {
+	c.record("List", projectID, opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(projectID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "List"))
+	}
+	return c.Default.List(projectID, opts)
+}
+
+// Update calls UpdateFunc, or Default.Update if it's nil.
+func (c *StorageClient) Update(request *cherrygo.UpdateStorage) (cherrygo.BlockStorage, *cherrygo.Response, error) {
+	c.record("Update", request)
+
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(request)
+	}
+	if c.Default == nil {
+		panic(unhandled("StorageClient", "Update"))
+	}
+	return c.Default.Update(request)
+}
+
+// TeamClient is a fake provider.TeamClient.
+type TeamClient struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.TeamClient
+
+	CreateFunc func(request *cherrygo.CreateTeam) (cherrygo.Team, *cherrygo.Response, error)
+	DeleteFunc func(teamID int) (*cherrygo.Response, error)
+	GetFunc    func(teamID int, opts *cherrygo.GetOptions) (cherrygo.Team, *cherrygo.Response, error)
+	ListFunc   func(opts *cherrygo.GetOptions) ([]cherrygo.Team, *cherrygo.Response, error)
+	UpdateFunc func(teamID int, request *cherrygo.UpdateTeam) (cherrygo.Team, *cherrygo.Response, error)
+}
+
+var _ provider.TeamClient = (*TeamClient)(nil)
+
+// Factory returns a factory that always returns c.
+func (c *TeamClient) Factory() provider.TeamClientFactory {
+	return func(context.Context) (provider.TeamClient, error) {
+		return c, nil
+	}
+}
+
+// Create calls CreateFunc, or Default.Create if it's nil.
+func (c *TeamClient) Create(request *cherrygo.CreateTeam) (cherrygo.Team, *cherrygo.Response, error) {
+	c.record("Create", request)
+
+	if c.CreateFunc != nil {
+		return c.CreateFunc(request)
+	}
+	if c.Default == nil {
+		panic(unhandled("TeamClient", "Create"))
+	}
+	return c.Default.Create(request)
+}
+
+// Delete calls DeleteFunc, or Default.Delete if it's nil.
+func (c *TeamClient) Delete(teamID int) (*cherrygo.Response, error) {
+	c.record("Delete", teamID)
+
+	if c.DeleteFunc != nil {
+		return c.DeleteFunc(teamID)
+	}
+	if c.Default == nil {
+		panic(unhandled("TeamClient", "Delete"))
+	}
+	return c.Default.Delete(teamID)
+}
+
+// Get calls GetFunc, or Default.Get if it's nil.
+func (c *TeamClient) Get(teamID int, opts *cherrygo.GetOptions) (cherrygo.Team, *cherrygo.Response, error) {
+	c.record("Get", teamID, opts)
+
+	if c.GetFunc != nil {
+		return c.GetFunc(teamID, opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("TeamClient", "Get"))
+	}
+	return c.Default.Get(teamID, opts)
+}
+
+// List calls ListFunc, or Default.List if it's nil.
+func (c *TeamClient) List(opts *cherrygo.GetOptions) ([]cherrygo.Team, *cherrygo.Response, error) {
+	c.record("List", opts)
+
+	if c.ListFunc != nil {
+		return c.ListFunc(opts)
+	}
+	if c.Default == nil {
+		panic(unhandled("TeamClient", "List"))
+	}
+	return c.Default.List(opts)
+}
+
+// Update calls UpdateFunc, or Default.Update if it's nil.
+func (c *TeamClient) Update(teamID int, request *cherrygo.UpdateTeam) (cherrygo.Team, *cherrygo.Response, error) {
+	c.record("Update", teamID, request)
+
+	if c.UpdateFunc != nil {
+		return c.UpdateFunc(teamID, request)
+	}
+	if c.Default == nil {
+		panic(unhandled("TeamClient", "Update"))
+	}
+	return c.Default.Update(teamID, request)
+}
@@ -0,0 +1,92 @@
+// Package fakeclient has test doubles of the API clients the provider uses.
+//
+// There's a fake for each client interface in the provider package, generated by ./gen.
+// Each fake records its calls, and calls the hook of a method if it's set, e.g. GetFunc for Get.
+// Methods without a hook call Default, and panic if that's nil too, so that a test
+// which sets neither can be sure the method isn't called. New sets the defaults to clients
+// of an in-memory fake API, which keeps state across calls and fakes.
+package fakeclient
+
+//go:generate go run ./gen -o clients.go
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+// Call is a recorded method call.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// Recorder records the calls made to a fake. The zero value is ready to use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *Recorder) record(method string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns the calls made so far, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.calls)
+}
+
+// CallsTo returns the calls made to a method so far, in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, call := range r.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func unhandled(fake, method string) string {
+	return fmt.Sprintf("fakeclient: %s.%s called without a hook or a default", fake, method)
+}
+
+// Clients are fakes of all the provider clients, sharing the state of a fake API by default.
+type Clients struct {
+	Projects *ProjectClient
+	Teams    *TeamClient
+	IPs      *IPClient
+	Regions  *RegionClient
+	Servers  *ServerClient
+	Storages *StorageClient
+}
+
+// New returns fakes that default to clients of api.
+func New(api *fakeapi.Server) (*Clients, error) {
+	client, err := cherrygo.NewClient(cherrygo.WithURL(api.URL), cherrygo.WithAuthToken("fake-token"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Clients{
+		Projects: &ProjectClient{Default: client.Projects},
+		Teams:    &TeamClient{Default: client.Teams},
+		IPs:      &IPClient{Default: provider.NewIPClient(client)},
+		Regions:  &RegionClient{Default: client.Regions},
+		Servers:  &ServerClient{Default: client.Servers},
+		Storages: &StorageClient{Default: client.Storages},
+	}, nil
+}
@@ -0,0 +1,61 @@
+package fakeclient_test
+
+import (
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeclient"
+	"github.com/cherryservers/cherrygo/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultsShareState(t *testing.T) {
+	api := fakeapi.New()
+	t.Cleanup(api.Close)
+	project := api.AddProject(api.AddTeam("test"), "test")
+
+	clients, err := fakeclient.New(api)
+	require.NoError(t, err)
+
+	ip, _, err := clients.IPs.Create(project, &cherrygo.CreateIPAddress{Region: "LT-Siauliai"})
+	require.NoError(t, err)
+
+	ips, _, err := clients.IPs.List(project, nil)
+	require.NoError(t, err)
+	require.Len(t, ips, 1)
+	assert.Equal(t, ip.ID, ips[0].ID)
+
+	assert.Equal(t, []fakeclient.Call{
+		{Method: "Create", Args: []any{project, &cherrygo.CreateIPAddress{Region: "LT-Siauliai"}}},
+		{Method: "List", Args: []any{project, (*cherrygo.GetOptions)(nil)}},
+	}, clients.IPs.Calls())
+}
+
+func TestHookOverridesDefault(t *testing.T) {
+	api := fakeapi.New()
+	t.Cleanup(api.Close)
+
+	clients, err := fakeclient.New(api)
+	require.NoError(t, err)
+
+	clients.Projects.GetFunc = func(projectID int, _ *cherrygo.GetOptions) (
+		cherrygo.Project, *cherrygo.Response, error) {
+		return cherrygo.Project{ID: projectID, Name: "hooked"}, nil, nil
+	}
+
+	project, _, err := clients.Projects.Get(42, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hooked", project.Name)
+	assert.Len(t, clients.Projects.CallsTo("Get"), 1)
+	assert.Empty(t, api.Projects(), "the API must not be called")
+}
+
+func TestNoHookOrDefaultPanics(t *testing.T) {
+	client := &fakeclient.ServerClient{}
+
+	assert.PanicsWithValue(t, "fakeclient: ServerClient.Delete called without a hook or a default", func() {
+		_, _, _ = client.Delete(1)
+	})
+	assert.Len(t, client.Calls(), 1)
+}
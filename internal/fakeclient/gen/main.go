@@ -0,0 +1,238 @@
+// Command gen generates the fakes of package fakeclient, one for each
+// client interface of the provider package, i.e. each exported interface named *Client.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+const providerPath = "github.com/caliban0/pulumi-cherry-servers/provider"
+
+func main() {
+	out := flag.String("o", "clients.go", "file to write the fakes to")
+	flag.Parse()
+
+	src, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err = os.WriteFile(*out, src, 0o600); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fake is what the template needs to know about a client interface.
+type fake struct {
+	Name string
+	// Factory is the name of the provider's factory type for the client, if it has one.
+	Factory string
+	Methods []method
+}
+
+type method struct {
+	Name string
+	// Params are the parameters, e.g. "ipID string, opts *cherrygo.GetOptions".
+	Params string
+	// Args are the parameters as arguments, e.g. "ipID, opts".
+	Args string
+	// Recorded are the parameters as recorded, where a variadic one is a single slice.
+	Recorded string
+	// Results are the results, e.g. "(cherrygo.IPAddress, *cherrygo.Response, error)".
+	Results    string
+	HasResults bool
+}
+
+func generate() ([]byte, error) {
+	pkg, err := load(providerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	imports := map[string]string{"context": "context", providerPath: "provider"}
+	qualifier := func(pkg *types.Package) string {
+		imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	}
+
+	scope := pkg.Scope()
+	var fakes []fake
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() || !strings.HasSuffix(name, "Client") {
+			continue
+		}
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+
+		f := fake{Name: name}
+		if _, ok = scope.Lookup(name + "Factory").(*types.TypeName); ok {
+			f.Factory = name + "Factory"
+		}
+		for m := range iface.Methods() {
+			f.Methods = append(f.Methods, newMethod(m, qualifier))
+		}
+		slices.SortFunc(f.Methods, func(a, b method) int { return strings.Compare(a.Name, b.Name) })
+
+		fakes = append(fakes, f)
+	}
+
+	// Standard library imports go first, in a group of their own.
+	var std, others []string
+	for path := range imports {
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			others = append(others, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(others)
+
+	var buf bytes.Buffer
+	if err = tmpl().Execute(&buf, map[string]any{"Std": std, "Imports": others, "Fakes": fakes}); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// load type checks a package from the export data of the go command,
+// which is always in a format the standard library can read.
+func load(path string) (*types.Package, error) {
+	cmd := exec.CommandContext(context.Background(),
+		"go", "list", "-export", "-deps", "-f", "{{.ImportPath}}={{.Export}}", path)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", path, err)
+	}
+
+	exports := map[string]string{}
+	for line := range strings.Lines(string(out)) {
+		importPath, file, _ := strings.Cut(strings.TrimSpace(line), "=")
+		exports[importPath] = file
+	}
+
+	lookup := func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok || file == "" {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	}
+
+	return importer.ForCompiler(token.NewFileSet(), "gc", lookup).Import(path)
+}
+
+func newMethod(m *types.Func, qualifier types.Qualifier) method {
+	sig, _ := m.Type().(*types.Signature)
+
+	var params, args, recorded []string
+	for i := range sig.Params().Len() {
+		param := sig.Params().At(i)
+
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+
+		typ := types.TypeString(param.Type(), qualifier)
+		arg := name
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+			arg += "..."
+		}
+
+		params = append(params, name+" "+typ)
+		args = append(args, arg)
+		recorded = append(recorded, name)
+	}
+
+	results := make([]string, 0, sig.Results().Len())
+	for result := range sig.Results().Variables() {
+		results = append(results, types.TypeString(result.Type(), qualifier))
+	}
+
+	return method{
+		Name:       m.Name(),
+		Params:     strings.Join(params, ", "),
+		Args:       strings.Join(args, ", "),
+		Recorded:   strings.Join(recorded, ", "),
+		Results:    "(" + strings.Join(results, ", ") + ")",
+		HasResults: len(results) > 0,
+	}
+}
+
+func tmpl() *template.Template {
+	return template.Must(template.New("fakes").Parse(`// Code generated by go run ./gen; DO NOT EDIT.
+
+package fakeclient
+
+import (
+{{- range .Std}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range $f := .Fakes}}
+// {{$f.Name}} is a fake provider.{{$f.Name}}.
+type {{$f.Name}} struct {
+	Recorder
+
+	// Default handles the calls of methods without a hook.
+	Default provider.{{$f.Name}}
+{{range $f.Methods}}
+	{{.Name}}Func func({{.Params}}) {{.Results}}
+{{- end}}
+}
+
+var _ provider.{{$f.Name}} = (*{{$f.Name}})(nil)
+{{if $f.Factory}}
+// Factory returns a factory that always returns c.
+func (c *{{$f.Name}}) Factory() provider.{{$f.Factory}} {
+	return func(context.Context) (provider.{{$f.Name}}, error) {
+		return c, nil
+	}
+}
+{{end}}
+{{- range $f.Methods}}
+// {{.Name}} calls {{.Name}}Func, or Default.{{.Name}} if it's nil.
+func (c *{{$f.Name}}) {{.Name}}({{.Params}}) {{.Results}} {
+	c.record("{{.Name}}"{{if .Recorded}}, {{.Recorded}}{{end}})
+
+	if c.{{.Name}}Func != nil {
+		{{if .HasResults}}return {{end}}c.{{.Name}}Func({{.Args}})
+		{{- if not .HasResults}}
+		return
+		{{- end}}
+	}
+	if c.Default == nil {
+		panic(unhandled("{{$f.Name}}", "{{.Name}}"))
+	}
+	{{if .HasResults}}return {{end}}c.Default.{{.Name}}({{.Args}})
+}
+{{end}}
+{{- end}}`))
+}
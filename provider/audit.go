@@ -0,0 +1,273 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+)
+
+// auditLogEnv enables the audit log, taking precedence over the auditLog config.
+const auditLogEnv = "CHERRY_AUDIT_LOG"
+
+// Audited actions.
+const (
+	auditCreate   = "create"
+	auditUpdate   = "update"
+	auditDelete   = "delete"
+	auditAssign   = "assign"
+	auditUnassign = "unassign"
+)
+
+// redacted replaces the values of secret fields in audit log payloads.
+const redacted = "[REDACTED]"
+
+// auditLog appends a JSON line for every API call that changes something.
+// A nil *auditLog doesn't record anything.
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openAuditLog opens the audit log for appending, creating it if needed.
+// An empty path disables it.
+func openAuditLog(path string) (*auditLog, error) {
+	if path == "" {
+		return nil, nil //nolint:nilnil // A nil log is disabled.
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	return &auditLog{file: file}, nil
+}
+
+type auditEntry struct {
+	Time    time.Time    `json:"time"`
+	URN     resource.URN `json:"urn,omitempty"`
+	Type    string       `json:"type,omitempty"`
+	Action  string       `json:"action"`
+	Method  string       `json:"method"`
+	Path    string       `json:"path"`
+	Payload any          `json:"payload,omitempty"`
+	Status  int          `json:"status,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	ID      string       `json:"id,omitempty"`
+}
+
+func (l *auditLog) write(entry auditEntry) error {
+	if l == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+type auditURNKey struct{}
+
+// withAuditURN tags API calls made by the operation in ctx with the URN of its resource.
+func withAuditURN(ctx context.Context, urn resource.URN) context.Context {
+	return context.WithValue(ctx, auditURNKey{}, urn)
+}
+
+// withAudit tags the API calls made by resource operations that change anything with their resource.
+func withAudit(prov p.Provider) p.Provider {
+	create, update, del := prov.Create, prov.Update, prov.Delete
+
+	if create != nil {
+		prov.Create = func(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
+			return create(withAuditURN(ctx, req.Urn), req)
+		}
+	}
+
+	if update != nil {
+		prov.Update = func(ctx context.Context, req p.UpdateRequest) (p.UpdateResponse, error) {
+			return update(withAuditURN(ctx, req.Urn), req)
+		}
+	}
+
+	if del != nil {
+		prov.Delete = func(ctx context.Context, req p.DeleteRequest) error {
+			return del(withAuditURN(ctx, req.Urn), req)
+		}
+	}
+
+	return prov
+}
+
+// auditTransport records API calls that change anything, once their retries are done.
+// Failed calls are recorded too, as their outcome might not be known.
+type auditTransport struct {
+	ctx    context.Context
+	log    *auditLog
+	logger Logger
+	next   http.RoundTripper
+}
+
+func (t auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.log == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+
+	var payload any
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			_ = json.NewDecoder(body).Decode(&payload)
+			_ = body.Close()
+		}
+	}
+
+	entry := auditEntry{
+		Time:    time.Now().UTC(),
+		Action:  auditAction(req.Method, req.URL.Path, payload),
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Payload: redact(payload),
+	}
+	if entry.Action != auditCreate {
+		entry.ID = auditID(req.URL.Path)
+	}
+	if urn, ok := t.ctx.Value(auditURNKey{}).(resource.URN); ok {
+		entry.URN = urn
+		entry.Type = urn.Type().String()
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		if entry.Action == auditCreate && resp.StatusCode < http.StatusMultipleChoices {
+			entry.ID = createdID(resp)
+		}
+	}
+
+	if writeErr := t.log.write(entry); writeErr != nil {
+		t.logger.Warningf("failed to write audit log: %v", writeErr)
+	}
+
+	return resp, err
+}
+
+var _ http.RoundTripper = auditTransport{}
+
+// attachmentsPath is where storage volumes are attached and detached, under the volume.
+const attachmentsPath = "/attachments"
+
+// auditAction names the change made by an API call.
+// IP addresses are assigned and unassigned with updates, so those are told apart by payload.
+// Storage volumes are attached and detached by creating and deleting their attachments.
+func auditAction(method, urlPath string, payload any) string {
+	if strings.HasSuffix(urlPath, attachmentsPath) {
+		if method == http.MethodDelete {
+			return auditUnassign
+		}
+		return auditAssign
+	}
+
+	switch method {
+	case http.MethodPost:
+		return auditCreate
+	case http.MethodDelete:
+		return auditDelete
+	}
+
+	fields, ok := payload.(map[string]any)
+	if !ok || !strings.HasPrefix(urlPath, "/v1/ips/") {
+		return auditUpdate
+	}
+
+	if len(fields) == 1 && fields["targeted_to"] == "0" {
+		return auditUnassign
+	}
+
+	for k := range fields {
+		switch k {
+		case "routed_to", "targeted_to", "assigned_to":
+		default:
+			return auditUpdate
+		}
+	}
+	return auditAssign
+}
+
+// auditID returns the ID of the resource changed by an API call to urlPath.
+func auditID(urlPath string) string {
+	return path.Base(strings.TrimSuffix(urlPath, attachmentsPath))
+}
+
+// createdID returns the ID of the resource in a create response, leaving the body readable.
+func createdID(resp *http.Response) string {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+
+	var created struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(body, &created) != nil || created.ID == nil {
+		return ""
+	}
+
+	return strings.Trim(string(created.ID), `"`)
+}
+
+// isSecretField reports whether a payload field might hold a secret.
+func isSecretField(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"password", "token", "secret", "user_data", "private_key"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns a copy of a decoded JSON payload, with the values of secret fields replaced.
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, field := range v {
+			if isSecretField(k) {
+				out[k] = redacted
+			} else {
+				out[k] = redact(field)
+			}
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = redact(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
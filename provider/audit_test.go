@@ -0,0 +1,172 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditEntry is the part of an audit log entry the tests check.
+type auditEntry struct {
+	Action  string         `json:"action"`
+	ID      string         `json:"id"`
+	Status  int            `json:"status"`
+	Payload map[string]any `json:"payload"`
+}
+
+// audit sends a request through the audit transport, and returns what it logged.
+func audit(t *testing.T, method, path, body string, resp *http.Response) []auditEntry {
+	t.Helper()
+
+	logPath := filepath.Join(t.TempDir(), "audit.log")
+	transport, err := provider.NewAuditTransport(t.Context(), logPath, FakeLogger{},
+		&stubTransport{responses: []*http.Response{resp}})
+	require.NoError(t, err)
+
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), method, "https://api.example.com"+path, reqBody)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+
+	var entries []auditEntry
+	for line := range strings.Lines(string(data)) {
+		var entry auditEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestAuditActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		resp       *http.Response
+		wantAction string
+		wantID     string
+	}{
+		{
+			name:       "create",
+			method:     http.MethodPost,
+			path:       "/v1/projects/1/ips",
+			body:       `{"region":"LT-Siauliai"}`,
+			resp:       jsonResponse(http.StatusCreated, `{"id":"ip-1"}`),
+			wantAction: "create",
+			wantID:     "ip-1",
+		},
+		{
+			name:       "update",
+			method:     http.MethodPut,
+			path:       "/v1/ips/ip-1",
+			body:       `{"ptr_record":"ptr.example.com","tags":{}}`,
+			resp:       stubResponse(http.StatusOK),
+			wantAction: "update",
+			wantID:     "ip-1",
+		},
+		{
+			name:       "IP address assigned",
+			method:     http.MethodPut,
+			path:       "/v1/ips/ip-1",
+			body:       `{"targeted_to":"7"}`,
+			resp:       stubResponse(http.StatusOK),
+			wantAction: "assign",
+			wantID:     "ip-1",
+		},
+		{
+			name:       "IP address unassigned",
+			method:     http.MethodPut,
+			path:       "/v1/ips/ip-1",
+			body:       `{"targeted_to":"0"}`,
+			resp:       stubResponse(http.StatusOK),
+			wantAction: "unassign",
+			wantID:     "ip-1",
+		},
+		{
+			name:       "storage attached",
+			method:     http.MethodPost,
+			path:       "/v1/storages/5/attachments",
+			body:       `{"attach_to":9}`,
+			resp:       jsonResponse(http.StatusCreated, `{"id":5}`),
+			wantAction: "assign",
+			wantID:     "5",
+		},
+		{
+			name:       "storage detached",
+			method:     http.MethodDelete,
+			path:       "/v1/storages/5/attachments",
+			resp:       stubResponse(http.StatusNoContent),
+			wantAction: "unassign",
+			wantID:     "5",
+		},
+		{
+			name:       "delete",
+			method:     http.MethodDelete,
+			path:       "/v1/ips/ip-1",
+			resp:       stubResponse(http.StatusNoContent),
+			wantAction: "delete",
+			wantID:     "ip-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := audit(t, tt.method, tt.path, tt.body, tt.resp)
+
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.wantAction, entries[0].Action)
+			assert.Equal(t, tt.wantID, entries[0].ID)
+			assert.Equal(t, tt.resp.StatusCode, entries[0].Status)
+		})
+	}
+}
+
+func TestAuditSkipsReads(t *testing.T) {
+	assert.Empty(t, audit(t, http.MethodGet, "/v1/ips/ip-1", "", stubResponse(http.StatusOK)))
+}
+
+func TestAuditRedactsSecrets(t *testing.T) {
+	const body = `{
+		"hostname": "web-1",
+		"password": "hunter2",
+		"user_data": "I2Nsb3VkLWNvbmZpZw==",
+		"ssh_keys": [1, 2],
+		"credentials": {"api_token": "abc", "username": "admin"},
+		"networks": [{"private_key": "-----BEGIN", "name": "lan"}]
+	}`
+
+	entries := audit(t, http.MethodPost, "/v1/projects/1/servers", body,
+		jsonResponse(http.StatusCreated, `{"id":101}`))
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		"hostname":    "web-1",
+		"password":    "[REDACTED]",
+		"user_data":   "[REDACTED]",
+		"ssh_keys":    []any{1.0, 2.0},
+		"credentials": map[string]any{"api_token": "[REDACTED]", "username": "admin"},
+		"networks":    []any{map[string]any{"private_key": "[REDACTED]", "name": "lan"}},
+	}, entries[0].Payload)
+	assert.Equal(t, "101", entries[0].ID)
+}
@@ -9,13 +9,26 @@ import (
 )
 
 func main() {
+	ctx := context.Background()
+
+	shutdown, err := p.InitTracing(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
+		os.Exit(1)
+	}
+
 	provider, err := p.Provider()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
 		os.Exit(1)
 	}
 
-	err = provider.Run(context.Background(), p.Name, p.Version)
+	err = provider.Run(ctx, p.Name, p.Version)
+
+	if shutdownErr := shutdown(ctx); shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to flush traces: %s", shutdownErr.Error())
+	}
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %s", err.Error())
 		os.Exit(1)
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/cherryservers/cherrygo/v3"
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+// ErrorKind classifies Cherry Servers API failures.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindNotFound
+	ErrorKindConflict
+	ErrorKindUnauthorized
+	ErrorKindRateLimited
+	ErrorKindServer
+	ErrorKindTransport
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "not found"
+	case ErrorKindConflict:
+		return "conflict"
+	case ErrorKindUnauthorized:
+		return "unauthorized"
+	case ErrorKindRateLimited:
+		return "rate limited"
+	case ErrorKindServer:
+		return "server error"
+	case ErrorKindTransport:
+		return "transport error"
+	case ErrorKindUnknown:
+	}
+	return "unknown error"
+}
+
+// errorKindFromStatus classifies a failed response by its status code.
+func errorKindFromStatus(code int) ErrorKind {
+	switch {
+	case code == http.StatusNotFound:
+		return ErrorKindNotFound
+	case code == http.StatusConflict:
+		return ErrorKindConflict
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrorKindUnauthorized
+	case code == http.StatusTooManyRequests:
+		return ErrorKindRateLimited
+	case code >= http.StatusInternalServerError:
+		return ErrorKindServer
+	}
+	return ErrorKindUnknown
+}
+
+// APIError is a failed Cherry Servers API call.
+type APIError struct {
+	Kind ErrorKind
+	// StatusCode is zero if no response was received.
+	StatusCode int
+	// Message is the error message returned by the API, if any.
+	Message string
+	Err     error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString("cherry servers API: ")
+	b.WriteString(e.Kind.String())
+
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " (status %d)", e.StatusCode)
+	}
+
+	switch {
+	case e.Message != "":
+		b.WriteString(": " + e.Message)
+	case e.Err != nil:
+		b.WriteString(": " + e.Err.Error())
+	}
+
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether repeating the failed call might succeed.
+func (e *APIError) Retryable() bool {
+	switch e.Kind {
+	case ErrorKindRateLimited, ErrorKindServer, ErrorKindTransport:
+		return true
+	case ErrorKindUnknown, ErrorKindNotFound, ErrorKindConflict, ErrorKindUnauthorized:
+	}
+	return false
+}
+
+// apiMessageRegexp extracts the API message from a cherrygo error.
+var apiMessageRegexp = regexp.MustCompile(`Error response from API: (.*) \(error code: \d+\)`)
+
+// apiError classifies the result of a cherrygo call.
+// It returns nil if err is nil. r may be nil, e.g. on network errors.
+func apiError(r *cherrygo.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if r == nil || r.Response == nil {
+		return &APIError{Kind: ErrorKindTransport, Err: err}
+	}
+
+	apiErr := &APIError{
+		Kind:       errorKindFromStatus(r.StatusCode),
+		StatusCode: r.StatusCode,
+		Err:        err,
+	}
+
+	if m := apiMessageRegexp.FindStringSubmatch(err.Error()); m != nil {
+		apiErr.Message = m[1]
+	}
+
+	return apiErr
+}
+
+// isNotFound reports whether err is an API error for a missing resource.
+func isNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Kind == ErrorKindNotFound
+}
+
+// initFailed wraps err, which happened after the resource was created.
+// Returned from Create along with the ID and partial state, it makes Pulumi
+// track the resource, so that it isn't orphaned.
+// The next update then picks up where the create left off, or deletes the resource.
+func initFailed(err error) error {
+	return fmt.Errorf("%w: %w", infer.ResourceInitFailedError{Reasons: []string{err.Error()}}, err)
+}
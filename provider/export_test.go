@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Unexported parts of the provider, for the tests in provider_test.
+
+const IPStateVersion = ipStateVersion
+
+//nolint:gochecknoglobals // Test exports.
+var (
+	NewRateLimiter = newRateLimiter
+)
+
+func (l *rateLimiter) Wait(ctx context.Context, logger Logger) error {
+	return l.wait(ctx, logger)
+}
+
+func NewRateLimitedTransport(limiter *rateLimiter, logger Logger, next http.RoundTripper) http.RoundTripper {
+	return rateLimitedTransport{limiter: limiter, logger: logger, next: next}
+}
+
+func NewRetryTransport(
+	ctx context.Context, logger Logger, attempts int, backoff func(int) time.Duration, next http.RoundTripper,
+) http.RoundTripper {
+	return retryTransport{ctx: ctx, logger: logger, attempts: attempts, backoff: backoff, next: next}
+}
+
+// PollUntil polls f every delay, without jitter, reporting progress to logger.
+func PollUntil(
+	ctx context.Context, logger Logger, waitingFor string, delay time.Duration, f func(context.Context) (bool, error),
+) error {
+	noJitter := func() time.Duration { return 0 }
+	poll := newPoller(withProgress(logger, waitingFor), withJitter(noJitter), withDelay(constantDelay(delay)))
+	return poll.until(ctx, f)
+}
+
+// NewAuditTransport records the API calls made through next in the audit log at path.
+func NewAuditTransport(ctx context.Context, path string, logger Logger, next http.RoundTripper) (
+	http.RoundTripper, error) {
+	log, err := openAuditLog(path)
+	if err != nil {
+		return nil, err
+	}
+	return auditTransport{ctx: ctx, log: log, logger: logger, next: next}, nil
+}
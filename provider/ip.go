@@ -2,9 +2,15 @@ package provider
 
 import (
 	"context"
-	"maps"
+	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cherryservers/cherrygo/v3"
 	prov "github.com/pulumi/pulumi-go-provider"
@@ -13,36 +19,88 @@ import (
 
 type IPClient interface {
 	cherrygo.IpAddressesService
+	// ClearRecords removes the selected DNS records of an IP address.
+	ClearRecords(ipID string, request *ClearIPRecords) (cherrygo.IPAddress, *cherrygo.Response, error)
 }
 
-type IPClientFactory func(cfg Config) (IPClient, error)
+// ClearIPRecords selects the DNS records to remove from an IP address.
+type ClearIPRecords struct {
+	PTRRecord bool
+	ARecord   bool
+}
+
+// ipClient adds the calls that cherrygo can't express to its IP address service.
+type ipClient struct {
+	cherrygo.IpAddressesService
+
+	client *cherrygo.Client
+}
+
+// NewIPClient returns the IP address client of an API client.
+func NewIPClient(client *cherrygo.Client) IPClient {
+	return ipClient{IpAddressesService: client.IPAddresses, client: client}
+}
+
+// ClearRecords sets the records to null. cherrygo omits empty strings from
+// update requests, so they can't be used to clear anything.
+func (c ipClient) ClearRecords(ipID string, request *ClearIPRecords) (
+	cherrygo.IPAddress, *cherrygo.Response, error) {
+	body := map[string]any{}
+	if request.PTRRecord {
+		body["ptr_record"] = nil
+	}
+	if request.ARecord {
+		body["a_record"] = nil
+	}
+
+	var ip cherrygo.IPAddress
+	r, err := c.client.MakeRequest(http.MethodPut, "/v1/ips/"+url.PathEscape(ipID), body, &ip)
+	return ip, r, err
+}
+
+type IPClientFactory func(ctx context.Context) (IPClient, error)
 
 type IP struct {
 	GetClient IPClientFactory
+	// GetRegionClient is used to check that regions exist. Optional.
+	GetRegionClient RegionClientFactory
+	GetLogger       GetLoggerFunc
+
+	regions regionCache
 }
 
 func (i *IP) Annotate(a infer.Annotator) {
 	a.Describe(&i, "Cherry Servers IP address.")
+	a.SetToken("network", "IP")
+	a.AddAlias("provider", "IP")
 }
 
 type IPArgs struct {
-	Region     string            `pulumi:"region"`
-	Project    int               `pulumi:"project"`
-	PTRRecord  string            `pulumi:"ptrRecord,optional"`
-	ARecord    string            `pulumi:"aRecord,optional"`
-	RoutedTo   string            `pulumi:"routedTo,optional"`
-	TargetedTo int               `pulumi:"targetedTo,optional"`
-	Tags       map[string]string `pulumi:"tags,optional"`
+	Region             string            `pulumi:"region"`
+	Project            int               `pulumi:"project"`
+	PTRRecord          string            `pulumi:"ptrRecord,optional"`
+	ARecord            string            `pulumi:"aRecord,optional"`
+	RoutedTo           string            `pulumi:"routedTo,optional"`
+	TargetedTo         int               `pulumi:"targetedTo,optional"`
+	Tags               map[string]string `pulumi:"tags,optional"`
+	DeletionProtection bool              `pulumi:"deletionProtection,optional"`
+	RetainOnDelete     bool              `pulumi:"retainOnDelete,optional"`
 }
 
 func (i *IPArgs) Annotate(a infer.Annotator) {
 	a.Describe(&i.Region, "IP address region slug.")
-	a.Describe(&i.Region, "IP address project ID.")
-	a.Describe(&i.PTRRecord, "IP address PTR record.")
-	a.Describe(&i.ARecord, "IP address A record.")
-	a.Describe(&i.RoutedTo, "IP address that this address is routed to.")
-	a.Describe(&i.TargetedTo, "Server that this address is targeted to.")
-	a.Describe(&i.Tags, "IP address tags.")
+	a.Describe(&i.Project, "IP address project ID.")
+	a.Describe(&i.PTRRecord, "IP address PTR record. Removing it clears the record.")
+	a.Describe(&i.ARecord, "IP address A record. Removing it clears the record.")
+	a.Describe(&i.RoutedTo, "IP address that this address is routed to. "+
+		"Conflicts with targetedTo. Removing both unassigns the address.")
+	a.Describe(&i.TargetedTo, "Server that this address is targeted to. "+
+		"Conflicts with routedTo. Removing both unassigns the address.")
+	a.Describe(&i.Tags, "IP address tags. Removing them clears all tags.")
+	a.Describe(&i.DeletionProtection, "Whether the IP address can't be deleted or replaced. "+
+		"It has to be disabled in an update before the address can be released.")
+	a.Describe(&i.RetainOnDelete, "Whether deleting the resource keeps the IP address, instead of releasing it. "+
+		"The address is unassigned and tagged with "+retainedTag+", so that it can be found and imported later.")
 }
 
 type IPState struct {
@@ -51,6 +109,8 @@ type IPState struct {
 	AddressFamily int    `pulumi:"addressFamily"`
 	CIDR          string `pulumi:"cidr"`
 	Type          string `pulumi:"type"`
+	// StateVersion is checked by the state migrations, see ipStateVersion.
+	StateVersion int `pulumi:"stateVersion,optional"`
 }
 
 func (i *IPState) Annotate(a infer.Annotator) {
@@ -59,6 +119,8 @@ func (i *IPState) Annotate(a infer.Annotator) {
 	a.Describe(&i.AddressFamily, "IP address family.")
 	a.Describe(&i.CIDR, "IP address CIDR.")
 	a.Describe(&i.Type, "IP address type.")
+	a.Describe(&i.StateVersion, "Version of the state layout, "+
+		"used to upgrade state written by older provider versions.")
 }
 
 var (
@@ -66,6 +128,7 @@ var (
 	_ infer.Annotated                             = (*IPArgs)(nil)
 	_ infer.Annotated                             = (*IPState)(nil)
 	_ infer.CustomCreate[IPArgs, IPState]         = (*IP)(nil)
+	_ infer.CustomCheck[IPArgs]                   = (*IP)(nil)
 	_ infer.CustomDelete[IPState]                 = (*IP)(nil)
 	_ infer.CustomUpdate[IPArgs, IPState]         = (*IP)(nil)
 	_ infer.CustomDiff[IPArgs, IPState]           = (*IP)(nil)
@@ -76,77 +139,301 @@ var (
 func (i *IP) Create(ctx context.Context, req infer.CreateRequest[IPArgs]) (
 	infer.CreateResponse[IPState], error) {
 	if req.DryRun {
+		// Server-assigned outputs are left zero, infer marks them unknown in previews.
 		return infer.CreateResponse[IPState]{
 			Output: IPState{
-				IPArgs: req.Inputs,
+				IPArgs:       req.Inputs,
+				StateVersion: ipStateVersion,
 			},
 		}, nil
 	}
 
-	client, err := i.GetClient(infer.GetConfig[Config](ctx))
+	client, err := i.GetClient(ctx)
 	if err != nil {
 		return infer.CreateResponse[IPState]{}, err
 	}
 
-	ip, _, err := client.Create(req.Inputs.Project, &cherrygo.CreateIPAddress{
+	ip, r, err := client.Create(req.Inputs.Project, &cherrygo.CreateIPAddress{
 		Region:     req.Inputs.Region,
 		PtrRecord:  req.Inputs.PTRRecord,
 		ARecord:    req.Inputs.ARecord,
 		RoutedTo:   req.Inputs.RoutedTo,
-		TargetedTo: strconv.Itoa(req.Inputs.TargetedTo),
-		Tags:       &req.Inputs.Tags,
+		TargetedTo: formatTargetedTo(req.Inputs.TargetedTo),
+		Tags:       tagsPayload(req.Inputs.Tags, nil),
 	})
-	if err != nil {
+	if err = apiError(r, err); err != nil {
 		return infer.CreateResponse[IPState]{}, err
 	}
 
+	state := ipStateFromClientResp(ip, req.Inputs)
+
+	if req.Inputs.RoutedTo != "" || req.Inputs.TargetedTo != 0 {
+		assigned, waitErr := waitForIPAssignment(ctx, client, i.GetLogger(ctx), ip.ID, req.Inputs)
+		if waitErr != nil {
+			return infer.CreateResponse[IPState]{ID: ip.ID, Output: state}, initFailed(waitErr)
+		}
+		state = assigned
+	}
+
 	return infer.CreateResponse[IPState]{
 		ID:     ip.ID,
-		Output: ipStateFromClientResp(ip, req.Inputs.Project),
+		Output: state,
+	}, nil
+}
+
+// waitForIPAssignment polls the IP address until it's routed or targeted as requested.
+func waitForIPAssignment(
+	ctx context.Context, client IPClient, logger Logger, id string, args IPArgs) (IPState, error) {
+	const timeout = 5 * time.Minute
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var state IPState
+	poll := newPoller(withProgress(logger, "ip address "+id+" to be assigned"))
+	err := poll.until(ctx, func(context.Context) (bool, error) {
+		ip, r, err := client.Get(id, nil)
+		if err = apiError(r, err); err != nil {
+			return false, err
+		}
+
+		state = ipStateFromClientResp(ip, args)
+		if args.RoutedTo != "" && state.RoutedTo != args.RoutedTo {
+			return false, nil
+		}
+		if args.TargetedTo != 0 && state.TargetedTo != args.TargetedTo {
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return IPState{}, fmt.Errorf("failed waiting for ip address %s to be assigned: %w", id, err)
+	}
+
+	return state, nil
+}
+
+func (i *IP) Check(ctx context.Context, req infer.CheckRequest) (
+	infer.CheckResponse[IPArgs], error) {
+	args, failures, err := infer.DefaultCheck[IPArgs](ctx, req.NewInputs)
+	if err != nil {
+		return infer.CheckResponse[IPArgs]{
+			Inputs:   args,
+			Failures: failures,
+		}, err
+	}
+
+	failures = append(failures, i.checkRegion(ctx, args.Region)...)
+
+	for k := range args.Tags {
+		if isReservedTag(k) {
+			failures = append(failures, prov.CheckFailure{
+				Property: "tags",
+				Reason:   fmt.Sprintf("tag %q is reserved", k),
+			})
+		}
+	}
+	args.Tags = normalizeTags(args.Tags, args.Tags)
+
+	if args.PTRRecord != "" && !isHostname(args.PTRRecord) {
+		failures = append(failures, prov.CheckFailure{
+			Property: "ptrRecord",
+			Reason:   fmt.Sprintf("%q is not a valid hostname", args.PTRRecord),
+		})
+	}
+
+	if args.RoutedTo != "" && args.TargetedTo != 0 {
+		failures = append(failures,
+			prov.CheckFailure{Property: "routedTo", Reason: "conflicts with targetedTo, only one can be set"},
+			prov.CheckFailure{Property: "targetedTo", Reason: "conflicts with routedTo, only one can be set"},
+		)
+	}
+
+	return infer.CheckResponse[IPArgs]{
+		Inputs:   args,
+		Failures: failures,
 	}, nil
 }
 
+// checkRegion validates the region slug. If the region list can be fetched,
+// the region must be in it, otherwise that's left for the API to decide.
+func (i *IP) checkRegion(ctx context.Context, region string) []prov.CheckFailure {
+	if region == "" {
+		return nil
+	}
+
+	if !regionSlugRegexp.MatchString(region) {
+		return []prov.CheckFailure{{
+			Property: "region",
+			Reason:   fmt.Sprintf("%q is not a valid region slug, e.g. LT-Siauliai", region),
+		}}
+	}
+
+	if i.GetRegionClient == nil {
+		return nil
+	}
+
+	regions, err := i.regions.get(ctx, i.GetRegionClient)
+	if err != nil || slices.Contains(regions, region) {
+		return nil
+	}
+
+	return []prov.CheckFailure{{
+		Property: "region",
+		Reason:   fmt.Sprintf("unknown region %q, available regions: %s", region, strings.Join(regions, ", ")),
+	}}
+}
+
+// regionSlugRegexp matches region slugs, like LT-Siauliai or NL-Amsterdam.
+var regionSlugRegexp = regexp.MustCompile(`^[A-Za-z]{2}-[A-Za-z0-9-]+$`)
+
+// hostnameLabelRegexp matches a single RFC 1123 hostname label.
+var hostnameLabelRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+// isHostname reports whether s is a valid fully qualified hostname.
+// A trailing dot is allowed.
+func isHostname(s string) bool {
+	const maxLen = 253
+
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > maxLen {
+		return false
+	}
+
+	for label := range strings.SplitSeq(s, ".") {
+		if !hostnameLabelRegexp.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
 func (i *IP) Delete(ctx context.Context, req infer.DeleteRequest[IPState]) (infer.DeleteResponse, error) {
-	client, err := i.GetClient(infer.GetConfig[Config](ctx))
+	if req.State.DeletionProtection {
+		return infer.DeleteResponse{}, deletionProtectedError("ip address", req.ID)
+	}
+
+	client, err := i.GetClient(ctx)
 	if err != nil {
 		return infer.DeleteResponse{}, err
 	}
 
+	if req.State.RetainOnDelete {
+		err = retainIP(client, i.GetLogger(ctx), req.ID, req.State)
+		if isNotFound(err) {
+			i.GetLogger(ctx).Warningf("ip address %s already deleted", req.ID)
+			err = nil
+		}
+		return infer.DeleteResponse{}, err
+	}
+
 	r, err := client.Remove(req.ID)
-	if err != nil && r.StatusCode == http.StatusNotFound {
-		prov.GetLogger(ctx).Warningf("ip address %s already deleted", req.ID)
+	if err = apiError(r, err); isNotFound(err) {
+		i.GetLogger(ctx).Warningf("ip address %s already deleted", req.ID)
 		err = nil
 	}
 	return infer.DeleteResponse{}, err
 }
 
+// retainedTag marks IP addresses that were left in place when their resource was deleted.
+// Its value is the time of deletion.
+const retainedTag = "pulumi-retained"
+
+// retainIP releases the IP address from Pulumi's management without releasing it back to the pool.
+func retainIP(client IPClient, logger Logger, id string, state IPState) error {
+	logger.Infof("retaining ip address %s (%s), it's left in the project tagged %s",
+		id, state.Address, retainedTag)
+
+	if state.RoutedTo != "" || state.TargetedTo != 0 {
+		if err := apiError(client.Unassign(id)); err != nil {
+			return fmt.Errorf("failed to unassign retained ip address: %w", err)
+		}
+	}
+
+	live, err := liveTags(client, id)
+	if err != nil {
+		return err
+	}
+
+	tags := tagsPayload(state.Tags, live)
+	(*tags)[retainedTag] = time.Now().UTC().Format(time.RFC3339)
+
+	_, r, err := client.Update(id, &cherrygo.UpdateIPAddress{Tags: tags})
+	if err = apiError(r, err); err != nil {
+		return fmt.Errorf("failed to tag retained ip address: %w", err)
+	}
+	return nil
+}
+
+// liveTags returns the tags an IP address has now, including the reserved ones left out of the state.
+func liveTags(client IPClient, id string) (map[string]string, error) {
+	ip, r, err := client.Get(id, nil)
+	if err = apiError(r, err); err != nil {
+		return nil, fmt.Errorf("failed to read ip address tags: %w", err)
+	}
+
+	var tags map[string]string
+	if ip.Tags != nil {
+		tags = *ip.Tags
+	}
+	return tags, nil
+}
+
 func (i *IP) Update(
 	ctx context.Context, req infer.UpdateRequest[IPArgs, IPState]) (
 	infer.UpdateResponse[IPState], error) {
 	if req.DryRun {
-		return infer.UpdateResponse[IPState]{
-			Output: IPState{
-				IPArgs: req.Inputs,
-			},
-		}, nil
+		// Keep the server-assigned outputs. The ones affected by
+		// the changed inputs are marked unknown by WireDependencies.
+		state := req.State
+		state.IPArgs = req.Inputs
+		return infer.UpdateResponse[IPState]{Output: state}, nil
+	}
+
+	client, err := i.GetClient(ctx)
+	if err != nil {
+		return infer.UpdateResponse[IPState]{}, err
 	}
 
-	client, err := i.GetClient(infer.GetConfig[Config](ctx))
+	live, err := liveTags(client, req.ID)
 	if err != nil {
 		return infer.UpdateResponse[IPState]{}, err
 	}
 
-	ip, _, err := client.Update(req.ID, &cherrygo.UpdateIPAddress{
+	// Empty inputs are left out of the update request,
+	// so removed routing and records have to be cleared separately.
+	if req.Inputs.RoutedTo == "" && req.Inputs.TargetedTo == 0 &&
+		(req.State.RoutedTo != "" || req.State.TargetedTo != 0) {
+		if err = apiError(client.Unassign(req.ID)); err != nil {
+			return infer.UpdateResponse[IPState]{}, fmt.Errorf("failed to unassign ip address: %w", err)
+		}
+	}
+
+	ip, r, err := client.Update(req.ID, &cherrygo.UpdateIPAddress{
 		PtrRecord:  req.Inputs.PTRRecord,
-		ARecord:    req.State.ARecord,
+		ARecord:    req.Inputs.ARecord,
 		RoutedTo:   req.Inputs.RoutedTo,
-		TargetedTo: strconv.Itoa(req.Inputs.TargetedTo),
-		Tags:       &req.Inputs.Tags,
+		TargetedTo: formatTargetedTo(req.Inputs.TargetedTo),
+		Tags:       tagsPayload(req.Inputs.Tags, live),
 	})
+	if err = apiError(r, err); err != nil {
+		return infer.UpdateResponse[IPState]{}, err
+	}
+
+	records := ClearIPRecords{
+		PTRRecord: req.Inputs.PTRRecord == "" && req.State.PTRRecord != "",
+		ARecord:   req.Inputs.ARecord == "" && req.State.ARecord != "",
+	}
+	if records.PTRRecord || records.ARecord {
+		ip, r, err = client.ClearRecords(req.ID, &records)
+		if err = apiError(r, err); err != nil {
+			return infer.UpdateResponse[IPState]{}, fmt.Errorf("failed to clear ip address records: %w", err)
+		}
+	}
 
 	return infer.UpdateResponse[IPState]{
-		Output: ipStateFromClientResp(ip, req.Inputs.Project),
-	}, err
+		Output: ipStateFromClientResp(ip, req.Inputs),
+	}, nil
 }
 
 func (i *IP) Diff(
@@ -163,67 +450,146 @@ func (i *IP) Diff(
 	}
 
 	if req.Inputs.PTRRecord != req.State.PTRRecord {
-		diff["ptrRecord"] = prov.PropertyDiff{Kind: prov.Update}
+		diff["ptrRecord"] = optionalDiff(req.Inputs.PTRRecord == "")
 	}
 
 	if req.Inputs.ARecord != req.State.ARecord {
-		diff["aRecord"] = prov.PropertyDiff{Kind: prov.Update}
+		diff["aRecord"] = optionalDiff(req.Inputs.ARecord == "")
 	}
 
 	if req.Inputs.RoutedTo != req.State.RoutedTo {
-		diff["routedTo"] = prov.PropertyDiff{Kind: prov.Update}
+		diff["routedTo"] = optionalDiff(req.Inputs.RoutedTo == "")
 	}
 
 	if req.Inputs.TargetedTo != req.State.TargetedTo {
-		diff["targetedTo"] = prov.PropertyDiff{Kind: prov.Update}
+		diff["targetedTo"] = optionalDiff(req.Inputs.TargetedTo == 0)
+	}
+
+	if !tagsEqual(req.Inputs.Tags, req.State.Tags) {
+		diff["tags"] = optionalDiff(len(req.Inputs.Tags) == 0)
+	}
+
+	if req.Inputs.DeletionProtection != req.State.DeletionProtection {
+		diff["deletionProtection"] = prov.PropertyDiff{Kind: prov.Update}
+	}
+
+	if req.Inputs.RetainOnDelete != req.State.RetainOnDelete {
+		diff["retainOnDelete"] = prov.PropertyDiff{Kind: prov.Update}
 	}
 
-	if !maps.Equal(req.Inputs.Tags, req.State.Tags) {
-		diff["tags"] = prov.PropertyDiff{Kind: prov.Update}
+	if err := checkReplaceProtection(diff, req.State.DeletionProtection, "ip address", req.ID); err != nil {
+		return infer.DiffResponse{}, err
 	}
 
 	return infer.DiffResponse{
-		DeleteBeforeReplace: true,
+		// The replacement is created first, so the address isn't gone until there's a new one.
+		// A records are unique though, so if the replacement keeps it, the old address goes first.
+		DeleteBeforeReplace: req.Inputs.ARecord != "" && req.Inputs.ARecord == req.State.ARecord,
 		HasChanges:          len(diff) > 0,
 		DetailedDiff:        diff,
 	}, nil
 }
 
+// optionalDiff returns the diff of a changed optional property,
+// which is a removal if the property is no longer set.
+func optionalDiff(removed bool) prov.PropertyDiff {
+	if removed {
+		return prov.PropertyDiff{Kind: prov.Delete}
+	}
+	return prov.PropertyDiff{Kind: prov.Update}
+}
+
+// formatTargetedTo formats a server ID for IP address requests.
+// The API takes "0" as a request to unassign, so an unset ID is left empty instead.
+func formatTargetedTo(serverID int) string {
+	if serverID == 0 {
+		return ""
+	}
+	return strconv.Itoa(serverID)
+}
+
 func (i *IP) Read(
 	ctx context.Context, req infer.ReadRequest[IPArgs, IPState]) (
 	infer.ReadResponse[IPArgs, IPState], error) {
-	client, err := i.GetClient(infer.GetConfig[Config](ctx))
+	client, err := i.GetClient(ctx)
 	if err != nil {
 		return infer.ReadResponse[IPArgs, IPState]{}, err
 	}
 
 	ip, r, err := client.Get(req.ID, nil)
-	if err != nil && r.StatusCode == http.StatusNotFound {
-		return infer.ReadResponse[IPArgs, IPState]{}, nil
+	if err = apiError(r, err); err != nil {
+		if isNotFound(err) {
+			return infer.ReadResponse[IPArgs, IPState]{}, nil
+		}
+		return infer.ReadResponse[IPArgs, IPState]{}, err
 	}
 
+	project, err := ipProjectID(ip)
+	if err != nil {
+		if req.Inputs.Project == 0 {
+			return infer.ReadResponse[IPArgs, IPState]{}, err
+		}
+		project = req.Inputs.Project
+	}
+
+	known := req.Inputs
+	known.Project = project
+	state := ipStateFromClientResp(ip, known)
+
 	return infer.ReadResponse[IPArgs, IPState]{
 		ID:     req.ID,
-		Inputs: req.Inputs,
-		State:  ipStateFromClientResp(ip, req.Inputs.Project),
-	}, err
+		Inputs: state.IPArgs,
+		State:  state,
+	}, nil
 }
 
-func ipStateFromClientResp(ip cherrygo.IPAddress, projectID int) IPState {
+// ipProjectID returns the ID of the project that owns the IP address.
+// The API doesn't always embed the whole project, so fall back to its href.
+func ipProjectID(ip cherrygo.IPAddress) (int, error) {
+	if ip.Project.ID != 0 {
+		return ip.Project.ID, nil
+	}
+
+	id, err := strconv.Atoi(path.Base(ip.Project.Href))
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("failed to resolve project of ip address %s", ip.ID)
+	}
+
+	return id, nil
+}
+
+// ipStateFromClientResp builds the state from the API representation.
+// Inputs the API doesn't report are taken from known.
+func ipStateFromClientResp(ip cherrygo.IPAddress, known IPArgs) IPState {
+	targetedTo := ip.TargetedTo.ID
+	if ip.RoutedTo.ID != "" {
+		// A routed address is targeted to the server of the address it's routed to,
+		// but only the routing is an input.
+		targetedTo = 0
+	}
+
+	var tags map[string]string
+	if ip.Tags != nil {
+		tags = *ip.Tags
+	}
+
 	return IPState{
 		IPArgs: IPArgs{
-			Region:     ip.Region.Slug,
-			Project:    projectID,
-			PTRRecord:  ip.PtrRecord,
-			ARecord:    ip.ARecord,
-			RoutedTo:   ip.RoutedTo.ID,
-			TargetedTo: ip.TargetedTo.ID,
-			Tags:       *ip.Tags,
+			Region:             ip.Region.Slug,
+			Project:            known.Project,
+			PTRRecord:          ip.PtrRecord,
+			ARecord:            ip.ARecord,
+			RoutedTo:           ip.RoutedTo.ID,
+			TargetedTo:         targetedTo,
+			Tags:               normalizeTags(tags, known.Tags),
+			DeletionProtection: known.DeletionProtection,
+			RetainOnDelete:     known.RetainOnDelete,
 		},
 		Address:       ip.Address,
 		AddressFamily: ip.AddressFamily,
 		CIDR:          ip.Cidr,
 		Type:          ip.Type,
+		StateVersion:  ipStateVersion,
 	}
 }
 
@@ -236,7 +602,10 @@ func (*IP) WireDependencies(
 	f.OutputField(&state.RoutedTo).DependsOn(f.InputField(&args.RoutedTo), f.InputField(&args.TargetedTo))
 	f.OutputField(&state.TargetedTo).DependsOn(f.InputField(&args.RoutedTo), f.InputField(&args.TargetedTo))
 	f.OutputField(&state.Tags).DependsOn(f.InputField(&args.Tags))
+	f.OutputField(&state.DeletionProtection).DependsOn(f.InputField(&args.DeletionProtection))
+	f.OutputField(&state.RetainOnDelete).DependsOn(f.InputField(&args.RetainOnDelete))
 	f.OutputField(&state.Address).DependsOn(f.InputField(&args.Region), f.InputField(&args.Project))
 	f.OutputField(&state.AddressFamily).DependsOn(f.InputField(&args.Region), f.InputField(&args.Project))
 	f.OutputField(&state.CIDR).DependsOn(f.InputField(&args.Region), f.InputField(&args.Project))
+	f.OutputField(&state.Type).DependsOn(f.InputField(&args.Region), f.InputField(&args.Project))
 }
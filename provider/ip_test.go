@@ -1,71 +1,742 @@
 package provider_test
 
 import (
+	"errors"
+	"maps"
+	"net/http"
 	"testing"
 
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeclient"
 	"github.com/caliban0/pulumi-cherry-servers/provider"
 	"github.com/cherryservers/cherrygo/v3"
+	prov "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-type fakeIPClient struct {
+func ipCreateOK(_ int, request *cherrygo.CreateIPAddress) (
+	cherrygo.IPAddress, *cherrygo.Response, error) {
+	return cherrygo.IPAddress{
+		ID:        "ip-1",
+		Address:   "5.199.171.1",
+		Region:    cherrygo.Region{Slug: request.Region},
+		PtrRecord: request.PtrRecord,
+		ARecord:   request.ARecord,
+		Tags:      request.Tags,
+	}, nil, nil
 }
 
-func (fakeIPClient) List(projectID int, opts *cherrygo.GetOptions) (_ []cherrygo.IPAddress, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+// ipGetTags returns a Get hook for an IP address with tags.
+func ipGetTags(tags map[string]string) func(string, *cherrygo.GetOptions) (
+	cherrygo.IPAddress, *cherrygo.Response, error) {
+	return func(ipID string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+		return cherrygo.IPAddress{ID: ipID, Region: cherrygo.Region{Slug: "LT-Siauliai"}, Tags: &tags}, nil, nil
+	}
 }
 
-func (fakeIPClient) Get(ipID string, opts *cherrygo.GetOptions) (_ cherrygo.IPAddress, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestCreateIP(t *testing.T) {
+	cases := []struct {
+		name          string
+		req           infer.CreateRequest[provider.IPArgs]
+		resp          infer.CreateResponse[provider.IPState]
+		clientFactory provider.IPClientFactory
+	}{
+		{
+			name: "dry-run",
+			req:  infer.CreateRequest[provider.IPArgs]{DryRun: true},
+			resp: infer.CreateResponse[provider.IPState]{
+				Output: provider.IPState{StateVersion: provider.IPStateVersion},
+			},
+		},
+		{
+			name: "ok",
+			req: infer.CreateRequest[provider.IPArgs]{
+				Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1, Tags: map[string]string{}},
+			},
+			resp: infer.CreateResponse[provider.IPState]{
+				ID: "ip-1",
+				Output: provider.IPState{
+					IPArgs:       provider.IPArgs{Region: "LT-Siauliai", Project: 1},
+					Address:      "5.199.171.1",
+					StateVersion: provider.IPStateVersion,
+				},
+			},
+			clientFactory: (&fakeclient.IPClient{
+				CreateFunc: ipCreateOK,
+			}).Factory(),
+		},
+		{
+			name: "wait for assignment",
+			req: infer.CreateRequest[provider.IPArgs]{
+				Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1, TargetedTo: 7, Tags: map[string]string{}},
+			},
+			resp: infer.CreateResponse[provider.IPState]{
+				ID: "ip-1",
+				Output: provider.IPState{
+					IPArgs: provider.IPArgs{
+						Region: "LT-Siauliai", Project: 1, TargetedTo: 7,
+					},
+					Address:      "5.199.171.1",
+					StateVersion: provider.IPStateVersion,
+				},
+			},
+			clientFactory: (&fakeclient.IPClient{
+				CreateFunc: ipCreateOK,
+				GetFunc: func(ipID string, _ *cherrygo.GetOptions) (
+					cherrygo.IPAddress, *cherrygo.Response, error) {
+					return cherrygo.IPAddress{
+						ID:         ipID,
+						Address:    "5.199.171.1",
+						Region:     cherrygo.Region{Slug: "LT-Siauliai"},
+						TargetedTo: cherrygo.AssignedTo{ID: 7},
+						Tags:       &map[string]string{},
+					}, nil, nil
+				},
+			}).Factory(),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := provider.IP{GetClient: tt.clientFactory, GetLogger: GetFakeLogger}
+			resp, err := p.Create(t.Context(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.resp, resp)
+		})
+	}
 }
 
-func (fakeIPClient) Create(projectID int, request *cherrygo.CreateIPAddress) (_ cherrygo.IPAddress, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestCreateIPInitFailed(t *testing.T) {
+	clientFactory := (&fakeclient.IPClient{
+		CreateFunc: ipCreateOK,
+		GetFunc: func(_ string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			return cherrygo.IPAddress{}, nil, errors.New("connection refused")
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	// The IP address exists, so it must be reported back, even though the assignment failed.
+	resp, err := p.Create(t.Context(), infer.CreateRequest[provider.IPArgs]{
+		Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1, TargetedTo: 7, Tags: map[string]string{}},
+	})
+
+	require.ErrorAs(t, err, &infer.ResourceInitFailedError{})
+	assert.Equal(t, "ip-1", resp.ID)
+	assert.Equal(t, "5.199.171.1", resp.Output.Address)
 }
 
-func (fakeIPClient) Remove(ipID string) (_ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestReadIPImport(t *testing.T) {
+	ip := cherrygo.IPAddress{
+		ID:            "ip-1",
+		Address:       "5.199.171.1",
+		AddressFamily: 4,
+		Cidr:          "5.199.171.1/32",
+		Type:          "floating-ip",
+		Region:        cherrygo.Region{Slug: "LT-Siauliai"},
+		PtrRecord:     "ptr.example.com",
+		Tags:          &map[string]string{"env": "test"},
+	}
+
+	args := provider.IPArgs{
+		Region:    "LT-Siauliai",
+		Project:   1,
+		PTRRecord: "ptr.example.com",
+		Tags:      map[string]string{"env": "test"},
+	}
+
+	cases := []struct {
+		name    string
+		project cherrygo.Project
+	}{
+		{name: "project ID", project: cherrygo.Project{ID: 1}},
+		{name: "project href", project: cherrygo.Project{Href: "/projects/1"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clientFactory := (&fakeclient.IPClient{
+				GetFunc: func(_ string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+					resp := ip
+					resp.Project = tt.project
+					return resp, nil, nil
+				},
+			}).Factory()
+
+			p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+			// No inputs or state are known during import.
+			resp, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{ID: "ip-1"})
+			require.NoError(t, err)
+			assert.Equal(t, infer.ReadResponse[provider.IPArgs, provider.IPState]{
+				ID:     "ip-1",
+				Inputs: args,
+				State: provider.IPState{
+					IPArgs:        args,
+					Address:       "5.199.171.1",
+					AddressFamily: 4,
+					CIDR:          "5.199.171.1/32",
+					Type:          "floating-ip",
+					StateVersion:  provider.IPStateVersion,
+				},
+			}, resp)
+		})
+	}
 }
 
-func (fakeIPClient) Update(ipID string, request *cherrygo.UpdateIPAddress) (_ cherrygo.IPAddress, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestReadIPUnknownProject(t *testing.T) {
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: func(ipID string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			return cherrygo.IPAddress{ID: ipID, Tags: &map[string]string{}}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	_, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{ID: "ip-1"})
+	require.Error(t, err)
+
+	// Fall back to the known project on refresh.
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: provider.IPArgs{Project: 1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Inputs.Project)
+	assert.Equal(t, 1, resp.State.Project)
 }
 
-func (fakeIPClient) Assign(ipID string, request *cherrygo.AssignIPAddress) (_ cherrygo.IPAddress, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestReadIPNotFound(t *testing.T) {
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: func(_ string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			return cherrygo.IPAddress{}, &cherrygo.Response{
+				Response: &http.Response{StatusCode: http.StatusNotFound},
+			}, errors.New("")
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{ID: "ip-1"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.ID)
 }
 
-func (fakeIPClient) Unassign(ipID string) (_ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
+func TestReadIPDrift(t *testing.T) {
+	config := provider.IPArgs{
+		Region:     "LT-Siauliai",
+		Project:    1,
+		PTRRecord:  "ptr.example.com",
+		ARecord:    "a.example.com",
+		TargetedTo: 7,
+		Tags:       map[string]string{"env": "prod"},
+	}
+
+	// Edited in the console: PTR record changed, tag removed and the address routed elsewhere.
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: func(ipID string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			return cherrygo.IPAddress{
+				ID:         ipID,
+				Region:     cherrygo.Region{Slug: "LT-Siauliai"},
+				Project:    cherrygo.Project{ID: 1},
+				PtrRecord:  "other.example.com",
+				ARecord:    "a.example.com",
+				RoutedTo:   cherrygo.RoutedTo{ID: "ip-2"},
+				TargetedTo: cherrygo.AssignedTo{ID: 8},
+				Tags:       &map[string]string{},
+			}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: config,
+		State:  provider.IPState{IPArgs: config},
+	})
+	require.NoError(t, err)
+
+	live := provider.IPArgs{
+		Region:    "LT-Siauliai",
+		Project:   1,
+		PTRRecord: "other.example.com",
+		ARecord:   "a.example.com",
+		RoutedTo:  "ip-2",
+	}
+	assert.Equal(t, live, resp.Inputs)
+	assert.Equal(t, live, resp.State.IPArgs)
+
+	// The next update must bring the address back in line with the program.
+	diff, err := p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: config,
+		State:  resp.State,
+	})
+	require.NoError(t, err)
+	assert.True(t, diff.HasChanges)
+	assert.Equal(t, map[string]prov.PropertyDiff{
+		"ptrRecord":  {Kind: prov.Update},
+		"routedTo":   {Kind: prov.Delete},
+		"targetedTo": {Kind: prov.Update},
+		"tags":       {Kind: prov.Update},
+	}, diff.DetailedDiff)
 }
 
+func TestUpdateIPSendsInputs(t *testing.T) {
+	var got *cherrygo.UpdateIPAddress
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: ipGetTags(nil),
+		UpdateFunc: func(ipID string, request *cherrygo.UpdateIPAddress) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			got = request
+			return cherrygo.IPAddress{
+				ID:        ipID,
+				Region:    cherrygo.Region{Slug: "LT-Siauliai"},
+				PtrRecord: request.PtrRecord,
+				ARecord:   request.ARecord,
+				Tags:      request.Tags,
+			}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	inputs := provider.IPArgs{
+		Region:    "LT-Siauliai",
+		Project:   1,
+		PTRRecord: "ptr.example.com",
+		ARecord:   "new.example.com",
+	}
+	state := provider.IPState{IPArgs: inputs}
+	state.ARecord = "old.example.com"
 
-func fakeIPClientFactory (cfg provider.Config) (provider.IPClient, error) {
-	return fakeIPClient{}, nil
+	resp, err := p.Update(t.Context(), infer.UpdateRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: inputs,
+		State:  state,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "new.example.com", got.ARecord)
+	assert.Equal(t, inputs, resp.Output.IPArgs)
 }
 
-func TestCreateIP(t *testing.T) {
-	p := provider.IP{GetClient: fakeIPClientFactory}
+func newFakeRegionClient() *fakeclient.RegionClient {
+	return &fakeclient.RegionClient{
+		ListFunc: func(_ *cherrygo.GetOptions) ([]cherrygo.Region, *cherrygo.Response, error) {
+			return []cherrygo.Region{{Slug: "LT-Siauliai"}, {Slug: "NL-Amsterdam"}}, nil, nil
+		},
+	}
+}
 
+func TestCheckIP(t *testing.T) {
 	cases := []struct {
-		name string
-		req infer.CreateRequest[provider.IPArgs]
-		resp infer.CreateResponse[provider.IPState]
-	} {
+		name     string
+		inputs   map[string]property.Value
+		failures []prov.CheckFailure
+	}{
 		{
-			name: "dry-run",
-			req: infer.CreateRequest[provider.IPArgs]{DryRun: true},
-			resp: infer.CreateResponse[provider.IPState]{},
+			name: "ok",
+			inputs: map[string]property.Value{
+				"ptrRecord":  property.New("ptr.example.com."),
+				"targetedTo": property.New(7.0),
+			},
+		},
+		{
+			name: "routed and targeted",
+			inputs: map[string]property.Value{
+				"routedTo":   property.New("ip-2"),
+				"targetedTo": property.New(7.0),
+			},
+			failures: []prov.CheckFailure{
+				{Property: "routedTo", Reason: "conflicts with targetedTo, only one can be set"},
+				{Property: "targetedTo", Reason: "conflicts with routedTo, only one can be set"},
+			},
+		},
+		{
+			name:   "invalid ptr record",
+			inputs: map[string]property.Value{"ptrRecord": property.New("-bad_host.example.com")},
+			failures: []prov.CheckFailure{
+				{Property: "ptrRecord", Reason: `"-bad_host.example.com" is not a valid hostname`},
+			},
+		},
+		{
+			name:   "invalid region slug",
+			inputs: map[string]property.Value{"region": property.New("Siauliai")},
+			failures: []prov.CheckFailure{
+				{Property: "region", Reason: `"Siauliai" is not a valid region slug, e.g. LT-Siauliai`},
+			},
+		},
+		{
+			name:   "unknown region",
+			inputs: map[string]property.Value{"region": property.New("US-Chicago")},
+			failures: []prov.CheckFailure{
+				{
+					Property: "region",
+					Reason:   `unknown region "US-Chicago", available regions: LT-Siauliai, NL-Amsterdam`,
+				},
+			},
 		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := p.Create(t.Context(), tt.req)
-			assert.NoError(t, err)
-			assert.Equal(t, tt.resp, resp)
+			inputs := map[string]property.Value{
+				"region":  property.New("LT-Siauliai"),
+				"project": property.New(1.0),
+			}
+			maps.Copy(inputs, tt.inputs)
+
+			p := provider.IP{GetRegionClient: newFakeRegionClient().Factory()}
+
+			resp, err := p.Check(t.Context(), infer.CheckRequest{Name: "ip", NewInputs: property.NewMap(inputs)})
+			require.NoError(t, err)
+			assert.Equal(t, tt.failures, resp.Failures)
 		})
 	}
+}
+
+func TestCheckIPCachesRegions(t *testing.T) {
+	regions := newFakeRegionClient()
+	p := provider.IP{GetRegionClient: regions.Factory()}
 
-}
\ No newline at end of file
+	for range 3 {
+		_, err := p.Check(t.Context(), infer.CheckRequest{
+			Name: "ip",
+			NewInputs: property.NewMap(map[string]property.Value{
+				"region":  property.New("LT-Siauliai"),
+				"project": property.New(1.0),
+			}),
+		})
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, regions.CallsTo("List"), 1)
+}
+
+func TestUpdateIPClearsRemovedInputs(t *testing.T) {
+	var (
+		unassigned bool
+		update     *cherrygo.UpdateIPAddress
+		cleared    *provider.ClearIPRecords
+	)
+
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: ipGetTags(map[string]string{"env": "test"}),
+		UnassignFunc: func(_ string) (*cherrygo.Response, error) {
+			unassigned = true
+			return &cherrygo.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		UpdateFunc: func(ipID string, request *cherrygo.UpdateIPAddress) (
+			cherrygo.IPAddress, *cherrygo.Response, error) {
+			update = request
+			return cherrygo.IPAddress{
+				ID:        ipID,
+				Region:    cherrygo.Region{Slug: "LT-Siauliai"},
+				PtrRecord: "ptr.example.com",
+				ARecord:   "a.example.com",
+				Tags:      request.Tags,
+			}, nil, nil
+		},
+		ClearRecordsFunc: func(ipID string, request *provider.ClearIPRecords) (
+			cherrygo.IPAddress, *cherrygo.Response, error) {
+			cleared = request
+			return cherrygo.IPAddress{
+				ID:     ipID,
+				Region: cherrygo.Region{Slug: "LT-Siauliai"},
+				Tags:   &map[string]string{},
+			}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	inputs := provider.IPArgs{Region: "LT-Siauliai", Project: 1}
+	resp, err := p.Update(t.Context(), infer.UpdateRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: inputs,
+		State: provider.IPState{IPArgs: provider.IPArgs{
+			Region:     "LT-Siauliai",
+			Project:    1,
+			PTRRecord:  "ptr.example.com",
+			ARecord:    "a.example.com",
+			TargetedTo: 7,
+			Tags:       map[string]string{"env": "test"},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, unassigned)
+	require.NotNil(t, update)
+	assert.Empty(t, update.TargetedTo, "an unset server must not be sent as \"0\"")
+	assert.Equal(t, &map[string]string{}, update.Tags)
+	assert.Equal(t, &provider.ClearIPRecords{PTRRecord: true, ARecord: true}, cleared)
+	assert.Equal(t, inputs, resp.Output.IPArgs)
+}
+
+func TestDiffIPRemovals(t *testing.T) {
+	p := provider.IP{}
+
+	resp, err := p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+		Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1, ARecord: "new.example.com"},
+		State: provider.IPState{IPArgs: provider.IPArgs{
+			Region:     "LT-Siauliai",
+			Project:    1,
+			PTRRecord:  "ptr.example.com",
+			ARecord:    "old.example.com",
+			RoutedTo:   "ip-2",
+			TargetedTo: 7,
+			Tags:       map[string]string{"env": "test"},
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]prov.PropertyDiff{
+		"ptrRecord":  {Kind: prov.Delete},
+		"aRecord":    {Kind: prov.Update},
+		"routedTo":   {Kind: prov.Delete},
+		"targetedTo": {Kind: prov.Delete},
+		"tags":       {Kind: prov.Delete},
+	}, resp.DetailedDiff)
+}
+
+func TestDeletionProtectionIP(t *testing.T) {
+	// No client callbacks, the API must not be called.
+	p := provider.IP{GetClient: (&fakeclient.IPClient{}).Factory(), GetLogger: GetFakeLogger}
+
+	protected := provider.IPState{IPArgs: provider.IPArgs{
+		Region: "LT-Siauliai", Project: 1, DeletionProtection: true,
+	}}
+
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.IPState]{ID: "ip-1", State: protected})
+	require.ErrorContains(t, err, "deletion protection")
+
+	_, err = p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: provider.IPArgs{Region: "NL-Amsterdam", Project: 1},
+		State:  protected,
+	})
+	require.ErrorContains(t, err, "deletion protection", "replacement must be refused")
+
+	resp, err := p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1},
+		State:  protected,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]prov.PropertyDiff{
+		"deletionProtection": {Kind: prov.Update},
+	}, resp.DetailedDiff)
+}
+
+func TestDeleteIPRetain(t *testing.T) {
+	var (
+		unassigned bool
+		tags       map[string]string
+	)
+
+	// No Remove callback, the address must not be released.
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: ipGetTags(map[string]string{"env": "test", "cherryservers:region-pool": "lt-1"}),
+		UnassignFunc: func(_ string) (*cherrygo.Response, error) {
+			unassigned = true
+			return &cherrygo.Response{Response: &http.Response{StatusCode: http.StatusOK}}, nil
+		},
+		UpdateFunc: func(ipID string, request *cherrygo.UpdateIPAddress) (
+			cherrygo.IPAddress, *cherrygo.Response, error) {
+			tags = *request.Tags
+			return cherrygo.IPAddress{ID: ipID, Tags: request.Tags}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.IPState]{
+		ID: "ip-1",
+		State: provider.IPState{IPArgs: provider.IPArgs{
+			Region:         "LT-Siauliai",
+			Project:        1,
+			TargetedTo:     7,
+			Tags:           map[string]string{"env": "test"},
+			RetainOnDelete: true,
+		}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, unassigned)
+	assert.Equal(t, "test", tags["env"])
+	assert.Contains(t, tags, "pulumi-retained")
+	assert.Equal(t, "lt-1", tags["cherryservers:region-pool"], "platform tags must be kept")
+}
+
+func TestUpdateIPKeepsPlatformTags(t *testing.T) {
+	var got *cherrygo.UpdateIPAddress
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: ipGetTags(map[string]string{"env": "test", "cherryservers:region-pool": "lt-1"}),
+		UpdateFunc: func(ipID string, request *cherrygo.UpdateIPAddress) (
+			cherrygo.IPAddress, *cherrygo.Response, error) {
+			got = request
+			return cherrygo.IPAddress{
+				ID:        ipID,
+				Region:    cherrygo.Region{Slug: "LT-Siauliai"},
+				PtrRecord: request.PtrRecord,
+				Tags:      request.Tags,
+			}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+	// Only the PTR record changes.
+	state := provider.IPState{IPArgs: provider.IPArgs{
+		Region: "LT-Siauliai", Project: 1, PTRRecord: "old.example.com", Tags: map[string]string{"env": "test"},
+	}}
+	inputs := state.IPArgs
+	inputs.PTRRecord = "new.example.com"
+
+	resp, err := p.Update(t.Context(), infer.UpdateRequest[provider.IPArgs, provider.IPState]{
+		ID:     "ip-1",
+		Inputs: inputs,
+		State:  state,
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, got)
+	assert.Equal(t, &map[string]string{"env": "test", "cherryservers:region-pool": "lt-1"}, got.Tags)
+	assert.Equal(t, map[string]string{"env": "test"}, resp.Output.Tags, "platform tags stay out of the state")
+}
+
+func TestDiffIPReplaceOrder(t *testing.T) {
+	cases := []struct {
+		name                string
+		aRecord             string
+		newARecord          string
+		deleteBeforeReplace bool
+	}{
+		{name: "no A record"},
+		{name: "new A record", aRecord: "old", newARecord: "new"},
+		{name: "A record removed", aRecord: "old"},
+		{name: "same A record", aRecord: "web", newARecord: "web", deleteBeforeReplace: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := provider.IP{}
+
+			resp, err := p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+				Inputs: provider.IPArgs{Region: "NL-Amsterdam", Project: 1, ARecord: tt.newARecord},
+				State: provider.IPState{IPArgs: provider.IPArgs{
+					Region: "LT-Siauliai", Project: 1, ARecord: tt.aRecord,
+				}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, prov.PropertyDiff{Kind: prov.UpdateReplace}, resp.DetailedDiff["region"])
+			assert.Equal(t, tt.deleteBeforeReplace, resp.DeleteBeforeReplace)
+		})
+	}
+}
+
+func TestReadIPNormalizesTags(t *testing.T) {
+	cases := []struct {
+		name  string
+		known map[string]string
+		live  *map[string]string
+		tags  map[string]string
+	}{
+		{name: "nil", live: nil, tags: nil},
+		{name: "empty", live: &map[string]string{}, tags: nil},
+		{
+			name: "reserved",
+			live: &map[string]string{"env": "test", "cherryservers:managed": "true", "pulumi-retained": "x"},
+			tags: map[string]string{"env": "test"},
+		},
+		{
+			name:  "key case",
+			known: map[string]string{"Env": "test"},
+			live:  &map[string]string{"env": "test"},
+			tags:  map[string]string{"Env": "test"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clientFactory := (&fakeclient.IPClient{
+				GetFunc: func(ipID string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+					return cherrygo.IPAddress{ID: ipID, Project: cherrygo.Project{ID: 1}, Tags: tt.live}, nil, nil
+				},
+			}).Factory()
+
+			p := provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+			args := provider.IPArgs{Project: 1, Tags: tt.known}
+			resp, err := p.Read(t.Context(), infer.ReadRequest[provider.IPArgs, provider.IPState]{
+				ID:     "ip-1",
+				Inputs: args,
+				State:  provider.IPState{IPArgs: args},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.tags, resp.Inputs.Tags)
+			assert.Equal(t, tt.tags, resp.State.Tags)
+		})
+	}
+}
+
+func TestDiffIPTags(t *testing.T) {
+	cases := []struct {
+		name    string
+		inputs  map[string]string
+		state   map[string]string
+		changed bool
+	}{
+		{name: "nil and empty", inputs: nil, state: map[string]string{}},
+		{name: "empty and nil", inputs: map[string]string{}, state: nil},
+		{name: "key case", inputs: map[string]string{"Env": "test"}, state: map[string]string{"env": "test"}},
+		{
+			name:   "reserved",
+			inputs: map[string]string{"env": "test"},
+			state:  map[string]string{"env": "test", "cherryservers:managed": "true"},
+		},
+		{
+			name:    "value changed",
+			inputs:  map[string]string{"env": "prod"},
+			state:   map[string]string{"env": "test"},
+			changed: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			p := provider.IP{}
+
+			resp, err := p.Diff(t.Context(), infer.DiffRequest[provider.IPArgs, provider.IPState]{
+				Inputs: provider.IPArgs{Region: "LT-Siauliai", Project: 1, Tags: tt.inputs},
+				State: provider.IPState{IPArgs: provider.IPArgs{
+					Region: "LT-Siauliai", Project: 1, Tags: tt.state,
+				}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.changed, resp.HasChanges)
+		})
+	}
+}
+
+func TestCheckIPReservedTags(t *testing.T) {
+	p := provider.IP{}
+
+	resp, err := p.Check(t.Context(), infer.CheckRequest{
+		Name: "ip",
+		NewInputs: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(1.0),
+			"tags": property.New(map[string]property.Value{
+				"pulumi-retained": property.New("x"),
+			}),
+		}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []prov.CheckFailure{
+		{Property: "tags", Reason: `tag "pulumi-retained" is reserved`},
+	}, resp.Failures)
+}
@@ -5,8 +5,15 @@ import (
 	p "github.com/pulumi/pulumi-go-provider"
 )
 
+// Logger reports to the Pulumi engine, attached to the resource whose operation is in the context.
+// Status messages show the progress of the resource while it's being worked on,
+// but aren't kept in the output once the operation is done.
 type Logger interface {
+	Debugf(msg string, a ...any)
+	Infof(msg string, a ...any)
 	Warningf(msg string, a ...any)
+	Errorf(msg string, a ...any)
+	InfoStatusf(msg string, a ...any)
 }
 
 type PulumiLogger struct {
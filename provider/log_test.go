@@ -2,19 +2,116 @@ package provider_test
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
 
+	"github.com/blang/semver"
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
 	"github.com/caliban0/pulumi-cherry-servers/provider"
+	prov "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type FakeLogger struct {
 }
 
+func (l FakeLogger) Debugf(_ string, _ ...any) {}
+
+func (l FakeLogger) Infof(_ string, _ ...any) {}
+
 func (l FakeLogger) Warningf(msg string, a ...any) {
 	// do nothing
 }
 
+func (l FakeLogger) Errorf(_ string, _ ...any) {}
+
+func (l FakeLogger) InfoStatusf(_ string, _ ...any) {}
+
 var _ provider.Logger = (*FakeLogger)(nil)
 
 func GetFakeLogger(_ context.Context) provider.Logger {
 	return FakeLogger{}
-}
\ No newline at end of file
+}
+
+// RecordingLogger keeps everything logged through it, for tests to check.
+type RecordingLogger struct {
+	mu       sync.Mutex
+	messages []LogMessage
+}
+
+// LogMessage is a formatted message, logged at Level.
+type LogMessage struct {
+	Level string
+	Text  string
+}
+
+func (l *RecordingLogger) Debugf(msg string, a ...any) { l.record("debug", msg, a) }
+
+func (l *RecordingLogger) Infof(msg string, a ...any) { l.record("info", msg, a) }
+
+func (l *RecordingLogger) Warningf(msg string, a ...any) { l.record("warning", msg, a) }
+
+func (l *RecordingLogger) Errorf(msg string, a ...any) { l.record("error", msg, a) }
+
+func (l *RecordingLogger) InfoStatusf(msg string, a ...any) { l.record("status", msg, a) }
+
+func (l *RecordingLogger) record(level, msg string, a []any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.messages = append(l.messages, LogMessage{Level: level, Text: fmt.Sprintf(msg, a...)})
+}
+
+// Messages returns the messages logged so far, in order.
+func (l *RecordingLogger) Messages() []LogMessage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]LogMessage(nil), l.messages...)
+}
+
+// Get can be used as a provider.GetLoggerFunc.
+func (l *RecordingLogger) Get(_ context.Context) provider.Logger {
+	return l
+}
+
+var _ provider.Logger = (*RecordingLogger)(nil)
+
+func TestProviderLogsThroughInjectedLogger(t *testing.T) {
+	api := fakeapi.New()
+	t.Cleanup(api.Close)
+	t.Setenv("CHERRY_API_URL", api.URL)
+	t.Setenv("CHERRY_AUTH_TOKEN", "fake-token")
+
+	project := api.AddProject(api.AddTeam("test"), "test")
+	id := api.AddIP(project, "LT-Siauliai")
+	// The retry is logged by the API client, not the resource.
+	api.Fail(http.MethodGet, "/v1/ips/"+id, http.StatusServiceUnavailable, 1)
+
+	logger := &RecordingLogger{}
+	p, err := provider.NewProvider(logger.Get)
+	require.NoError(t, err)
+	server, err := integration.NewServer(t.Context(), provider.Name, semver.MustParse("1.0.0"),
+		integration.WithProvider(p))
+	require.NoError(t, err)
+
+	_, err = server.Read(prov.ReadRequest{
+		ID:  id,
+		Urn: ipURN(),
+		Properties: property.NewMap(map[string]property.Value{
+			"region":  property.New("LT-Siauliai"),
+			"project": property.New(float64(project)),
+		}),
+	})
+	require.NoError(t, err)
+
+	messages := logger.Messages()
+	require.NotEmpty(t, messages)
+	assert.Equal(t, "warning", messages[0].Level)
+	assert.Contains(t, messages[0].Text, "GET /v1/ips/"+id+" failed with server error, retrying")
+}
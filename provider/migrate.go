@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/pulumi/pulumi-go-provider/infer"
+)
+
+// State written by older versions of the provider is upgraded before it's used,
+// so that existing stacks keep working as the state structs change.
+//
+// IP address states record the version of their layout in stateVersion, which the migrations
+// decode and check, so that an old state is recognized by its version rather than its values.
+// When IPState changes in a way that old states no longer decode into, or have to be rewritten,
+// freeze the current shape as a struct named after its version, add an upgrade function from it
+// that only handles states of that version, and increase ipStateVersion.
+//
+// Project state has only gained optional fields so far, which old checkpoints decode into as is.
+
+// ipStateVersion is the version of the IPState layout, written to every IP address state.
+const ipStateVersion = 1
+
+// ipStateV0 is the IP address state written before states were versioned.
+// These versions kept targetedTo next to routedTo and wrote missing tags as an empty map,
+// which now show up as changes on every diff. The oldest have no deletionProtection and retainOnDelete.
+type ipStateV0 struct {
+	Region             string            `pulumi:"region"`
+	Project            int               `pulumi:"project"`
+	PTRRecord          string            `pulumi:"ptrRecord,optional"`
+	ARecord            string            `pulumi:"aRecord,optional"`
+	RoutedTo           string            `pulumi:"routedTo,optional"`
+	TargetedTo         int               `pulumi:"targetedTo,optional"`
+	Tags               map[string]string `pulumi:"tags,optional"`
+	DeletionProtection bool              `pulumi:"deletionProtection,optional"`
+	RetainOnDelete     bool              `pulumi:"retainOnDelete,optional"`
+	Address            string            `pulumi:"address"`
+	AddressFamily      int               `pulumi:"addressFamily"`
+	CIDR               string            `pulumi:"cidr"`
+	Type               string            `pulumi:"type"`
+	// StateVersion is zero for these states, it's only decoded to tell them apart from later ones.
+	StateVersion int `pulumi:"stateVersion,optional"`
+}
+
+var _ infer.CustomStateMigrations[IPState] = (*IP)(nil)
+
+func (*IP) StateMigrations(context.Context) []infer.StateMigrationFunc[IPState] {
+	return []infer.StateMigrationFunc[IPState]{
+		infer.StateMigration(upgradeIPStateV0),
+	}
+}
+
+func upgradeIPStateV0(_ context.Context, old ipStateV0) (infer.MigrationResult[IPState], error) {
+	if old.StateVersion != 0 {
+		return infer.MigrationResult[IPState]{}, nil
+	}
+
+	state := normalizeIPState(IPState{
+		IPArgs: IPArgs{
+			Region:             old.Region,
+			Project:            old.Project,
+			PTRRecord:          old.PTRRecord,
+			ARecord:            old.ARecord,
+			RoutedTo:           old.RoutedTo,
+			TargetedTo:         old.TargetedTo,
+			Tags:               old.Tags,
+			DeletionProtection: old.DeletionProtection,
+			RetainOnDelete:     old.RetainOnDelete,
+		},
+		Address:       old.Address,
+		AddressFamily: old.AddressFamily,
+		CIDR:          old.CIDR,
+		Type:          old.Type,
+	})
+	state.StateVersion = ipStateVersion
+	return infer.MigrationResult[IPState]{Result: &state}, nil
+}
+
+// normalizeIPState brings the values of an IP address state to the form written by ipStateFromClientResp.
+func normalizeIPState(state IPState) IPState {
+	if state.RoutedTo != "" {
+		state.TargetedTo = 0
+	}
+	state.Tags = normalizeTags(state.Tags, state.Tags)
+	return state
+}
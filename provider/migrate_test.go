@@ -0,0 +1,144 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeclient"
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/cherryservers/cherrygo/v3"
+	prov "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi-go-provider/infer"
+	"github.com/pulumi/pulumi-go-provider/integration"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+	"github.com/pulumi/pulumi/sdk/v3/go/property"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// State migrations only run when states are decoded by infer, so these go through a server.
+func newIPServer(t *testing.T, ip *provider.IP) integration.Server {
+	t.Helper()
+
+	p, err := infer.NewProviderBuilder().WithResources(infer.Resource(ip)).Build()
+	require.NoError(t, err)
+
+	server, err := integration.NewServer(t.Context(), provider.Name, semver.MustParse("1.0.0"),
+		integration.WithProvider(p))
+	require.NoError(t, err)
+
+	return server
+}
+
+func ipURN() resource.URN {
+	return resource.NewURN("test", "test", "", tokens.Type(provider.Name+":network:IP"), "ip")
+}
+
+// ipStateV0 is an IP address checkpoint written before deletion protection,
+// routed to another address and without tags.
+func ipStateV0() property.Map {
+	return property.NewMap(map[string]property.Value{
+		"region":        property.New("LT-Siauliai"),
+		"project":       property.New(1.0),
+		"routedTo":      property.New("ip-2"),
+		"targetedTo":    property.New(101.0),
+		"tags":          property.New(property.NewMap(nil)),
+		"address":       property.New("5.199.171.1"),
+		"addressFamily": property.New(4.0),
+		"cidr":          property.New("5.199.171.1/32"),
+		"type":          property.New("floating-ip"),
+	})
+}
+
+func TestDiffIPStateV0(t *testing.T) {
+	server := newIPServer(t, &provider.IP{})
+
+	inputs := property.NewMap(map[string]property.Value{
+		"region":   property.New("LT-Siauliai"),
+		"project":  property.New(1.0),
+		"routedTo": property.New("ip-2"),
+	})
+
+	cases := []struct {
+		name  string
+		state property.Map
+		input property.Map
+	}{
+		{name: "v0", state: ipStateV0(), input: inputs},
+		{
+			name:  "v0 with deletion protection",
+			state: ipStateV0().Set("deletionProtection", property.New(true)),
+			input: inputs.Set("deletionProtection", property.New(true)),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.Diff(prov.DiffRequest{
+				ID:     "ip-1",
+				Urn:    ipURN(),
+				State:  tt.state,
+				Inputs: tt.input,
+			})
+			require.NoError(t, err)
+			assert.False(t, resp.HasChanges, "diff: %v", resp.DetailedDiff)
+		})
+	}
+}
+
+func TestReadIPStateV0(t *testing.T) {
+	clientFactory := (&fakeclient.IPClient{
+		GetFunc: func(_ string, _ *cherrygo.GetOptions) (cherrygo.IPAddress, *cherrygo.Response, error) {
+			return cherrygo.IPAddress{
+				ID:            "ip-1",
+				Address:       "5.199.171.1",
+				AddressFamily: 4,
+				Cidr:          "5.199.171.1/32",
+				Type:          "floating-ip",
+				Region:        cherrygo.Region{Slug: "LT-Siauliai"},
+				Project:       cherrygo.Project{ID: 1},
+				RoutedTo:      cherrygo.RoutedTo{ID: "ip-2"},
+			}, nil, nil
+		},
+	}).Factory()
+
+	server := newIPServer(t, &provider.IP{GetClient: clientFactory, GetLogger: GetFakeLogger})
+
+	resp, err := server.Read(prov.ReadRequest{
+		ID:         "ip-1",
+		Urn:        ipURN(),
+		Properties: ipStateV0(),
+		Inputs: property.NewMap(map[string]property.Value{
+			"region":   property.New("LT-Siauliai"),
+			"project":  property.New(1.0),
+			"routedTo": property.New("ip-2"),
+		}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ip-2", resp.Properties.Get("routedTo").AsString())
+	assert.Zero(t, resp.Properties.Get("targetedTo").AsNumber())
+	assert.Zero(t, resp.Properties.Get("tags").AsMap().Len())
+	assert.Equal(t, "5.199.171.1", resp.Properties.Get("address").AsString())
+	assert.Equal(t, provider.IPStateVersion, int(resp.Properties.Get("stateVersion").AsNumber()))
+}
+
+func TestDiffIPStateVersioned(t *testing.T) {
+	server := newIPServer(t, &provider.IP{})
+
+	// The same values as v0, which only a v0 state is upgraded from.
+	state := ipStateV0().Set("stateVersion", property.New(float64(provider.IPStateVersion)))
+	resp, err := server.Diff(prov.DiffRequest{
+		ID:    "ip-1",
+		Urn:   ipURN(),
+		State: state,
+		Inputs: property.NewMap(map[string]property.Value{
+			"region":   property.New("LT-Siauliai"),
+			"project":  property.New(1.0),
+			"routedTo": property.New("ip-2"),
+		}),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, resp.DetailedDiff, "targetedTo", "a state of the current version must be decoded as is")
+}
@@ -34,6 +34,8 @@ func constantDelay(delay time.Duration) delayFunc {
 type poller struct {
 	jitter jitterFunc
 	delay  delayFunc
+	// progress is called before each repeated attempt. Optional.
+	progress func(attempt int, elapsed time.Duration)
 }
 
 // until polls until f returns true or a non-nil error.
@@ -49,11 +51,16 @@ func (p poller) until(ctx context.Context, f func(ctx context.Context) (bool, er
 			"context cancelled prior to condition being fulfilled: %w", ctx.Err())
 	}
 
+	start := time.Now()
 	timer := time.NewTimer(p.delay() + p.jitter())
 
-	for {
+	for attempt := 2; ; attempt++ {
 		select {
 		case <-timer.C:
+			if p.progress != nil {
+				p.progress(attempt, time.Since(start))
+			}
+
 			r, err := f(ctx)
 			if r || err != nil {
 				return err
@@ -70,6 +77,17 @@ func (p poller) until(ctx context.Context, f func(ctx context.Context) (bool, er
 
 type pollerOption func(p *poller)
 
+// withProgress reports each repeated attempt as the status of the resource,
+// e.g. "waiting for ip address 123 to be assigned (attempt 5, 50s elapsed)".
+func withProgress(logger Logger, waitingFor string) pollerOption {
+	return func(p *poller) {
+		p.progress = func(attempt int, elapsed time.Duration) {
+			logger.InfoStatusf("waiting for %s (attempt %d, %v elapsed)",
+				waitingFor, attempt, elapsed.Round(time.Second))
+		}
+	}
+}
+
 func withJitter(f jitterFunc) pollerOption {
 	return func(p *poller) {
 		p.jitter = f
@@ -0,0 +1,29 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollerReportsProgress(t *testing.T) {
+	logger := &RecordingLogger{}
+
+	calls := 0
+	err := provider.PollUntil(t.Context(), logger, "ip address ip-1 to be assigned", time.Millisecond,
+		func(context.Context) (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+	require.NoError(t, err)
+
+	// The first attempt is made right away, so only the repeated ones are reported.
+	assert.Equal(t, []LogMessage{
+		{Level: "status", Text: "waiting for ip address ip-1 to be assigned (attempt 2, 0s elapsed)"},
+		{Level: "status", Text: "waiting for ip address ip-1 to be assigned (attempt 3, 0s elapsed)"},
+	}, logger.Messages())
+}
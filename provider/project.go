@@ -3,7 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/cherryservers/cherrygo/v3"
@@ -17,25 +17,46 @@ type ProjectClient interface {
 
 type ProjectClientFactory func(ctx context.Context) (ProjectClient, error)
 
+type TeamClient interface {
+	cherrygo.TeamsService
+}
+
+type TeamClientFactory func(ctx context.Context) (TeamClient, error)
+
 type Project struct {
-	GetClient ProjectClientFactory
-	GetLogger GetLoggerFunc
+	GetClient     ProjectClientFactory
+	GetTeamClient TeamClientFactory
+	// The clients below are used to find and delete what's left in the project on delete.
+	GetServerClient  ServerClientFactory
+	GetIPClient      IPClientFactory
+	GetStorageClient StorageClientFactory
+	GetLogger        GetLoggerFunc
 }
 
 func (p *Project) Annotate(a infer.Annotator) {
 	a.Describe(&p, "A Cherry Servers project.")
+	a.SetToken("index", "Project")
+	// Resources used to be in the module of the Go package.
+	a.AddAlias("provider", "Project")
 }
 
 type ProjectArgs struct {
-	Name string `pulumi:"name,optional"`
-	Team int    `pulumi:"team"`
-	BGP  bool   `pulumi:"bgp,optional"`
+	Name               string `pulumi:"name,optional"`
+	Team               int    `pulumi:"team"`
+	BGP                bool   `pulumi:"bgp,optional"`
+	DeletionProtection bool   `pulumi:"deletionProtection,optional"`
+	ForceDestroy       bool   `pulumi:"forceDestroy,optional"`
 }
 
 func (p *ProjectArgs) Annotate(a infer.Annotator) {
-	a.Describe(&p.Name, "Project name.")
+	a.Describe(&p.Name, "Project name. If removed, the current name is kept.")
 	a.Describe(&p.Team, "ID of the team the project belongs to.")
-	a.Describe(&p.BGP, "Whether BGP should be enabled for the project.")
+	a.Describe(&p.BGP, "Whether BGP should be enabled for the project. Removing it disables BGP.")
+	a.Describe(&p.DeletionProtection, "Whether the project can't be deleted or replaced. "+
+		"It has to be disabled in an update before the project can be deleted.")
+	a.Describe(&p.ForceDestroy, "Whether deleting the project also deletes the servers, IP addresses "+
+		"and volumes left in it. Otherwise, deleting a project that isn't empty fails. "+
+		"It has to be enabled in an update before the delete.")
 }
 
 type ProjectState struct {
@@ -64,6 +85,7 @@ var (
 func (p *Project) Create(ctx context.Context, req infer.CreateRequest[ProjectArgs]) (
 	infer.CreateResponse[ProjectState], error) {
 	if req.DryRun {
+		// Server-assigned outputs are left zero, infer marks them unknown in previews.
 		return infer.CreateResponse[ProjectState]{
 			Output: ProjectState{
 				ProjectArgs: req.Inputs,
@@ -76,21 +98,25 @@ func (p *Project) Create(ctx context.Context, req infer.CreateRequest[ProjectArg
 		return infer.CreateResponse[ProjectState]{}, err
 	}
 
-	project, _, err := client.Create(req.Inputs.Team, &cherrygo.CreateProject{
+	project, r, err := client.Create(req.Inputs.Team, &cherrygo.CreateProject{
 		Name: req.Inputs.Name,
 		Bgp:  req.Inputs.BGP,
 	})
-	if err != nil {
+	if err = apiError(r, err); err != nil {
 		return infer.CreateResponse[ProjectState]{}, err
 	}
 
 	return infer.CreateResponse[ProjectState]{
 		ID:     strconv.Itoa(project.ID),
-		Output: projectStateFromClientResp(project, req.Inputs.Team),
+		Output: projectStateFromClientResp(project, req.Inputs),
 	}, nil
 }
 
 func (p *Project) Delete(ctx context.Context, req infer.DeleteRequest[ProjectState]) (infer.DeleteResponse, error) {
+	if req.State.DeletionProtection {
+		return infer.DeleteResponse{}, deletionProtectedError("project", req.ID)
+	}
+
 	client, err := p.GetClient(ctx)
 	if err != nil {
 		return infer.DeleteResponse{}, err
@@ -101,8 +127,29 @@ func (p *Project) Delete(ctx context.Context, req infer.DeleteRequest[ProjectSta
 		return infer.DeleteResponse{}, fmt.Errorf("id not an int: %w", err)
 	}
 
+	contents, err := p.listContents(ctx, id)
+	if isNotFound(err) {
+		p.GetLogger(ctx).Warningf("project %s already deleted", req.ID)
+		return infer.DeleteResponse{}, nil
+	}
+	if err != nil {
+		return infer.DeleteResponse{}, err
+	}
+
+	if !contents.empty() {
+		if !req.State.ForceDestroy {
+			return infer.DeleteResponse{}, fmt.Errorf(
+				"project %s isn't empty, delete its contents or enable forceDestroy first: %s", req.ID, contents)
+		}
+
+		p.GetLogger(ctx).Warningf("force destroying project %s contents: %s", req.ID, contents)
+		if err = p.destroyContents(ctx, id, contents); err != nil {
+			return infer.DeleteResponse{}, err
+		}
+	}
+
 	r, err := client.Delete(id)
-	if err != nil && r.StatusCode == http.StatusNotFound {
+	if err = apiError(r, err); isNotFound(err) {
 		p.GetLogger(ctx).Warningf("project %s already deleted", req.ID)
 		err = nil
 	}
@@ -130,11 +177,11 @@ func (p *Project) Update(
 	ctx context.Context, req infer.UpdateRequest[ProjectArgs, ProjectState]) (
 	infer.UpdateResponse[ProjectState], error) {
 	if req.DryRun {
-		return infer.UpdateResponse[ProjectState]{
-			Output: ProjectState{
-				ProjectArgs: req.Inputs,
-			},
-		}, nil
+		// Keep the server-assigned outputs. The ones affected by
+		// the changed inputs are marked unknown by WireDependencies.
+		state := req.State
+		state.ProjectArgs = req.Inputs
+		return infer.UpdateResponse[ProjectState]{Output: state}, nil
 	}
 
 	client, err := p.GetClient(ctx)
@@ -147,14 +194,17 @@ func (p *Project) Update(
 		return infer.UpdateResponse[ProjectState]{}, err
 	}
 
-	project, _, err := client.Update(id, &cherrygo.UpdateProject{
+	project, r, err := client.Update(id, &cherrygo.UpdateProject{
 		Name: &req.Inputs.Name,
 		Bgp:  &req.Inputs.BGP,
 	})
+	if err = apiError(r, err); err != nil {
+		return infer.UpdateResponse[ProjectState]{}, err
+	}
 
 	return infer.UpdateResponse[ProjectState]{
-		Output: projectStateFromClientResp(project, req.Inputs.Team),
-	}, err
+		Output: projectStateFromClientResp(project, req.Inputs),
+	}, nil
 }
 
 func (p *Project) Diff(
@@ -174,10 +224,22 @@ func (p *Project) Diff(
 		diff["team"] = prov.PropertyDiff{Kind: prov.UpdateReplace}
 	}
 
+	if req.Inputs.DeletionProtection != req.State.DeletionProtection {
+		diff["deletionProtection"] = prov.PropertyDiff{Kind: prov.Update}
+	}
+
+	if req.Inputs.ForceDestroy != req.State.ForceDestroy {
+		diff["forceDestroy"] = prov.PropertyDiff{Kind: prov.Update}
+	}
+
+	if err := checkReplaceProtection(diff, req.State.DeletionProtection, "project", req.ID); err != nil {
+		return infer.DiffResponse{}, err
+	}
+
+	// Project names don't have to be unique, so the replacement can be created first.
 	return infer.DiffResponse{
-		DeleteBeforeReplace: true,
-		HasChanges:          len(diff) > 0,
-		DetailedDiff:        diff,
+		HasChanges:   len(diff) > 0,
+		DetailedDiff: diff,
 	}, nil
 }
 
@@ -195,24 +257,113 @@ func (p *Project) Read(
 	}
 
 	project, r, err := client.Get(id, nil)
-	if err != nil && r.StatusCode == http.StatusNotFound {
-		p.GetLogger(ctx).Warningf("project %s not found", req.ID)
-		return infer.ReadResponse[ProjectArgs, ProjectState]{}, nil
+	if err = apiError(r, err); err != nil {
+		if isNotFound(err) {
+			p.GetLogger(ctx).Warningf("project %s not found", req.ID)
+			return infer.ReadResponse[ProjectArgs, ProjectState]{}, nil
+		}
+		return infer.ReadResponse[ProjectArgs, ProjectState]{}, err
 	}
 
+	// The team isn't part of the project response, and there are no inputs
+	// to take it from during import, so look it up.
+	team, err := p.findTeam(ctx, client, id, req.Inputs.Team)
+	if err != nil {
+		return infer.ReadResponse[ProjectArgs, ProjectState]{}, err
+	}
+
+	known := req.Inputs
+	known.Team = team
+	state := projectStateFromClientResp(project, known)
+
 	return infer.ReadResponse[ProjectArgs, ProjectState]{
 		ID:     req.ID,
-		Inputs: req.Inputs,
-		State:  projectStateFromClientResp(project, req.Inputs.Team),
-	}, err
+		Inputs: state.ProjectArgs,
+		State:  state,
+	}, nil
+}
+
+// findTeam returns the ID of the team the project belongs to.
+// Only the hint team is checked, if it has the project. Otherwise, e.g. on import,
+// every team available to the API token is searched.
+func (p *Project) findTeam(ctx context.Context, client ProjectClient, projectID, hint int) (int, error) {
+	if hint != 0 {
+		found, err := teamHasProject(client, hint, projectID)
+		if err != nil || found {
+			return hint, err
+		}
+	}
+
+	teamClient, err := p.GetTeamClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	teams, err := listPages(func(opts *cherrygo.GetOptions) ([]cherrygo.Team, *cherrygo.Response, error) {
+		return teamClient.List(opts)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	for _, team := range teams {
+		if team.ID == hint {
+			continue
+		}
+
+		found, listErr := teamHasProject(client, team.ID, projectID)
+		if listErr != nil {
+			return 0, listErr
+		}
+		if found {
+			return team.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("project %d doesn't belong to any team available to the API token", projectID)
+}
+
+// teamHasProject reports whether the project belongs to the team.
+func teamHasProject(client ProjectClient, teamID, projectID int) (bool, error) {
+	projects, err := listPages(func(opts *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+		return client.List(teamID, opts)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list projects of team %d: %w", teamID, err)
+	}
+
+	return slices.ContainsFunc(projects, func(p cherrygo.Project) bool { return p.ID == projectID }), nil
+}
+
+// listPageSize is how many items listPages requests at a time.
+const listPageSize = 100
+
+// listPages calls list for each page, until it returns a partial one, and returns the items of all pages.
+func listPages[T any](list func(opts *cherrygo.GetOptions) ([]T, *cherrygo.Response, error)) ([]T, error) {
+	var all []T
+	for offset := 0; ; offset += listPageSize {
+		items, r, err := list(&cherrygo.GetOptions{Limit: listPageSize, Offset: offset})
+		if err = apiError(r, err); err != nil {
+			return nil, err
+		}
+
+		all = append(all, items...)
+		if len(items) < listPageSize {
+			return all, nil
+		}
+	}
 }
 
-func projectStateFromClientResp(p cherrygo.Project, teamID int) ProjectState {
+// projectStateFromClientResp builds the state from the API representation.
+// Inputs the API doesn't report are taken from known.
+func projectStateFromClientResp(p cherrygo.Project, known ProjectArgs) ProjectState {
 	return ProjectState{
 		ProjectArgs: ProjectArgs{
-			Name: p.Name,
-			Team: teamID,
-			BGP:  p.Bgp.Enabled,
+			Name:               p.Name,
+			Team:               known.Team,
+			BGP:                p.Bgp.Enabled,
+			DeletionProtection: known.DeletionProtection,
+			ForceDestroy:       known.ForceDestroy,
 		},
 		LocalASN: p.Bgp.LocalASN,
 	}
@@ -221,4 +372,6 @@ func projectStateFromClientResp(p cherrygo.Project, teamID int) ProjectState {
 func (*Project) WireDependencies(
 	f infer.FieldSelector, args *ProjectArgs, state *ProjectState) {
 	f.OutputField(&state.LocalASN).DependsOn(f.InputField(&args.BGP))
+	f.OutputField(&state.DeletionProtection).DependsOn(f.InputField(&args.DeletionProtection))
+	f.OutputField(&state.ForceDestroy).DependsOn(f.InputField(&args.ForceDestroy))
 }
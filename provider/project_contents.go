@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+type ServerClient interface {
+	cherrygo.ServersService
+}
+
+type ServerClientFactory func(ctx context.Context) (ServerClient, error)
+
+type StorageClient interface {
+	cherrygo.StoragesService
+}
+
+type StorageClientFactory func(ctx context.Context) (StorageClient, error)
+
+// projectContents are the resources left in a project.
+type projectContents struct {
+	servers []cherrygo.Server
+	// ips only has the addresses that exist on their own,
+	// server primary and private addresses go with the server.
+	ips     []cherrygo.IPAddress
+	volumes []cherrygo.BlockStorage
+}
+
+func (c projectContents) empty() bool {
+	return len(c.servers) == 0 && len(c.ips) == 0 && len(c.volumes) == 0
+}
+
+// String lists the contents, e.g. "servers: 1 (web-1); ip addresses: ip-1 (5.199.171.1)".
+func (c projectContents) String() string {
+	var parts []string
+
+	if len(c.servers) > 0 {
+		items := make([]string, 0, len(c.servers))
+		for _, s := range c.servers {
+			items = append(items, fmt.Sprintf("%d (%s)", s.ID, s.Hostname))
+		}
+		parts = append(parts, "servers: "+strings.Join(items, ", "))
+	}
+
+	if len(c.ips) > 0 {
+		items := make([]string, 0, len(c.ips))
+		for _, ip := range c.ips {
+			items = append(items, fmt.Sprintf("%s (%s)", ip.ID, ip.Address))
+		}
+		parts = append(parts, "ip addresses: "+strings.Join(items, ", "))
+	}
+
+	if len(c.volumes) > 0 {
+		items := make([]string, 0, len(c.volumes))
+		for _, v := range c.volumes {
+			items = append(items, fmt.Sprintf("%d (%s)", v.ID, v.Name))
+		}
+		parts = append(parts, "volumes: "+strings.Join(items, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// isServerIP reports whether the address belongs to a server and can't be removed on its own.
+func isServerIP(ip cherrygo.IPAddress) bool {
+	return ip.Type == "primary-ip" || ip.Type == "private-ip"
+}
+
+// listContents returns the resources left in the project.
+func (p *Project) listContents(ctx context.Context, projectID int) (projectContents, error) {
+	var contents projectContents
+
+	servers, err := p.GetServerClient(ctx)
+	if err != nil {
+		return projectContents{}, err
+	}
+
+	var r *cherrygo.Response
+	contents.servers, r, err = servers.List(projectID, nil)
+	if err = apiError(r, err); err != nil {
+		return projectContents{}, fmt.Errorf("failed to list project servers: %w", err)
+	}
+
+	ips, err := p.GetIPClient(ctx)
+	if err != nil {
+		return projectContents{}, err
+	}
+
+	var addresses []cherrygo.IPAddress
+	addresses, r, err = ips.List(projectID, nil)
+	if err = apiError(r, err); err != nil {
+		return projectContents{}, fmt.Errorf("failed to list project ip addresses: %w", err)
+	}
+	for _, ip := range addresses {
+		if !isServerIP(ip) {
+			contents.ips = append(contents.ips, ip)
+		}
+	}
+
+	storages, err := p.GetStorageClient(ctx)
+	if err != nil {
+		return projectContents{}, err
+	}
+
+	contents.volumes, r, err = storages.List(projectID, nil)
+	if err = apiError(r, err); err != nil {
+		return projectContents{}, fmt.Errorf("failed to list project volumes: %w", err)
+	}
+
+	return contents, nil
+}
+
+// destroyContents deletes the contents of a project in dependency order:
+// IP addresses and volumes are detached and deleted before the servers they're attached to.
+// It returns once the servers are gone, as the project can't be deleted before that.
+func (p *Project) destroyContents(ctx context.Context, projectID int, contents projectContents) error {
+	if err := p.destroyIPs(ctx, contents.ips); err != nil {
+		return err
+	}
+
+	if err := p.destroyVolumes(ctx, contents.volumes); err != nil {
+		return err
+	}
+
+	return p.destroyServers(ctx, projectID, contents.servers)
+}
+
+func (p *Project) destroyIPs(ctx context.Context, ips []cherrygo.IPAddress) error {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	client, err := p.GetIPClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range ips {
+		if ip.RoutedTo.ID != "" || ip.TargetedTo.ID != 0 {
+			if err = apiError(client.Unassign(ip.ID)); err != nil && !isNotFound(err) {
+				return fmt.Errorf("failed to unassign ip address %s: %w", ip.ID, err)
+			}
+		}
+
+		if err = apiError(client.Remove(ip.ID)); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to remove ip address %s: %w", ip.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) destroyVolumes(ctx context.Context, volumes []cherrygo.BlockStorage) error {
+	if len(volumes) == 0 {
+		return nil
+	}
+
+	client, err := p.GetStorageClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range volumes {
+		if v.AttachedTo.ID != 0 {
+			if err = apiError(client.Detach(v.ID)); err != nil && !isNotFound(err) {
+				return fmt.Errorf("failed to detach volume %d: %w", v.ID, err)
+			}
+		}
+
+		if err = apiError(client.Delete(v.ID)); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete volume %d: %w", v.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Project) destroyServers(ctx context.Context, projectID int, servers []cherrygo.Server) error {
+	const timeout = 10 * time.Minute
+
+	if len(servers) == 0 {
+		return nil
+	}
+
+	client, err := p.GetServerClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range servers {
+		_, r, deleteErr := client.Delete(s.ID)
+		if err = apiError(r, deleteErr); err != nil && !isNotFound(err) {
+			return fmt.Errorf("failed to delete server %d: %w", s.ID, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	poll := newPoller(withProgress(p.GetLogger(ctx), fmt.Sprintf("servers of project %d to be deleted", projectID)))
+	err = poll.until(ctx, func(context.Context) (bool, error) {
+		left, r, listErr := client.List(projectID, nil)
+		if listErr = apiError(r, listErr); listErr != nil {
+			return false, listErr
+		}
+		return len(left) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for servers of project %d to be deleted: %w", projectID, err)
+	}
+
+	return nil
+}
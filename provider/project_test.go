@@ -3,113 +3,68 @@ package provider_test
 // Unit tests for stuff that's tricky to cover with integration/lifecycle tests.
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
 	"testing"
 
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeapi"
+	"github.com/caliban0/pulumi-cherry-servers/internal/fakeclient"
 	"github.com/caliban0/pulumi-cherry-servers/provider"
 	"github.com/cherryservers/cherrygo/v3"
 	prov "github.com/pulumi/pulumi-go-provider"
 	"github.com/pulumi/pulumi-go-provider/infer"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-type projectCreateFunc func(teamID int, request *cherrygo.CreateProject) (cherrygo.Project, *cherrygo.Response, error)
-type projectDeleteFunc func(projectID int) (*cherrygo.Response, error)
-type projectGetFunc func(projectID int, opts *cherrygo.GetOptions) (_ cherrygo.Project, _ *cherrygo.Response, _ error)
-
-var projectCreateOK projectCreateFunc = func(teamID int, request *cherrygo.CreateProject) (cherrygo.Project, *cherrygo.Response, error) {
+func projectCreateOK(_ int, request *cherrygo.CreateProject) (cherrygo.Project, *cherrygo.Response, error) {
 	return cherrygo.Project{
 		ID:   0,
 		Name: request.Name,
 		Bgp:  cherrygo.ProjectBGP{Enabled: request.Bgp}}, nil, nil
 }
 
-type fakeProjectsClient struct {
-	createFunc projectCreateFunc
-	deleteFunc projectDeleteFunc
-	getFunc    projectGetFunc
-}
-
-func (c fakeProjectsClient) List(teamID int, opts *cherrygo.GetOptions) (
-	_ []cherrygo.Project, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
-}
-
-func (c fakeProjectsClient) Get(projectID int, opts *cherrygo.GetOptions) (
-	_ cherrygo.Project, _ *cherrygo.Response, _ error) {
-	if c.getFunc == nil {
-		panic("no Get callback for fakeProjectsClient")
-	}
-	return c.getFunc(projectID, opts)
-}
-
-func (c fakeProjectsClient) Create(teamID int, request *cherrygo.CreateProject) (
-	_ cherrygo.Project, _ *cherrygo.Response, _ error) {
-	if c.createFunc == nil {
-		panic("no Create callback for fakeProjectsClient")
-	}
-	return c.createFunc(teamID, request)
-}
-
-func (c fakeProjectsClient) Update(projectID int, request *cherrygo.UpdateProject) (
-	_ cherrygo.Project, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
-}
-
-func (c fakeProjectsClient) ListSSHKeys(projectID int, opts *cherrygo.GetOptions) (
-	_ []cherrygo.SSHKey, _ *cherrygo.Response, _ error) {
-	panic("not implemented") // TODO: Implement
-}
-
-func (c fakeProjectsClient) Delete(projectID int) (_ *cherrygo.Response, _ error) {
-	if c.deleteFunc == nil {
-		panic("no Delete callback for fakeProjectsClient")
-	}
-	return c.deleteFunc(projectID)
-}
-
-type fakeProjectsClientOption func(*fakeProjectsClient)
-
-func withCreateProject(f projectCreateFunc) fakeProjectsClientOption {
-	return func(client *fakeProjectsClient) {
-		client.createFunc = f
-	}
-}
-
-func withDeleteProject(f projectDeleteFunc) fakeProjectsClientOption {
-	return func(client *fakeProjectsClient) {
-		client.deleteFunc = f
-	}
-}
-
-func withGetProject(f projectGetFunc) fakeProjectsClientOption {
-	return func(client *fakeProjectsClient) {
-		client.getFunc = f
-	}
+func newFakeTeamClientFactory(teams ...cherrygo.Team) provider.TeamClientFactory {
+	return (&fakeclient.TeamClient{
+		ListFunc: func(_ *cherrygo.GetOptions) ([]cherrygo.Team, *cherrygo.Response, error) {
+			return teams, nil, nil
+		},
+	}).Factory()
 }
 
-func newFakeProjectsClientFactory(opts ...fakeProjectsClientOption) provider.ProjectClientFactory {
-	return func(_ context.Context) (provider.ProjectClient, error) {
-		f := fakeProjectsClient{}
-		for _, opt := range opts {
-			opt(&f)
-		}
-		return f, nil
-	}
+// withEmptyContents sets the project's content clients to fakes of an empty project.
+func withEmptyContents(p provider.Project) provider.Project {
+	p.GetServerClient = (&fakeclient.ServerClient{
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.Server, *cherrygo.Response, error) {
+			return nil, nil, nil
+		},
+	}).Factory()
+	p.GetIPClient = (&fakeclient.IPClient{
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.IPAddress, *cherrygo.Response, error) {
+			return nil, nil, nil
+		},
+	}).Factory()
+	p.GetStorageClient = (&fakeclient.StorageClient{
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.BlockStorage, *cherrygo.Response, error) {
+			return nil, nil, nil
+		},
+	}).Factory()
+	return p
 }
 
 func TestDeleteProjectNotFound(t *testing.T) {
-	clientFactory := newFakeProjectsClientFactory(withDeleteProject(
-		func(projectID int) (*cherrygo.Response, error) {
+	clientFactory := (&fakeclient.ProjectClient{
+		DeleteFunc: func(projectID int) (*cherrygo.Response, error) {
 			return &cherrygo.Response{
 				Response: &http.Response{StatusCode: http.StatusNotFound},
 			}, errors.New("")
 		},
-	))
+	}).Factory()
 
-	p := provider.Project{GetClient: clientFactory, GetLogger: GetFakeLogger}
+	p := withEmptyContents(provider.Project{GetClient: clientFactory, GetLogger: GetFakeLogger})
 
 	// Check that "not found" is handled gracefully in deletion operation.
 	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.ProjectState]{ID: "0"})
@@ -117,13 +72,13 @@ func TestDeleteProjectNotFound(t *testing.T) {
 }
 
 func TestReadProjectNotFound(t *testing.T) {
-	clientFactory := newFakeProjectsClientFactory(withGetProject(
-		func(projectID int, opts *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+	clientFactory := (&fakeclient.ProjectClient{
+		GetFunc: func(projectID int, opts *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
 			return cherrygo.Project{},
 				&cherrygo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}},
 				errors.New("")
 		},
-	))
+	}).Factory()
 
 	p := provider.Project{GetClient: clientFactory, GetLogger: GetFakeLogger}
 
@@ -132,6 +87,73 @@ func TestReadProjectNotFound(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDeleteProjectTransportError(t *testing.T) {
+	clientFactory := (&fakeclient.ProjectClient{
+		DeleteFunc: func(_ int) (*cherrygo.Response, error) {
+			return nil, errors.New("connection reset by peer")
+		},
+	}).Factory()
+
+	p := withEmptyContents(provider.Project{GetClient: clientFactory, GetLogger: GetFakeLogger})
+
+	// A missing response must not be mistaken for "not found", or panic.
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.ProjectState]{ID: "0"})
+
+	var apiErr *provider.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, provider.ErrorKindTransport, apiErr.Kind)
+	assert.True(t, apiErr.Retryable())
+}
+
+func TestReadProjectAPIError(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		kind      provider.ErrorKind
+		retryable bool
+	}{
+		{name: "conflict", status: http.StatusConflict, kind: provider.ErrorKindConflict},
+		{name: "unauthorized", status: http.StatusUnauthorized, kind: provider.ErrorKindUnauthorized},
+		{name: "forbidden", status: http.StatusForbidden, kind: provider.ErrorKindUnauthorized},
+		{
+			name:      "rate limited",
+			status:    http.StatusTooManyRequests,
+			kind:      provider.ErrorKindRateLimited,
+			retryable: true,
+		},
+		{name: "server", status: http.StatusBadGateway, kind: provider.ErrorKindServer, retryable: true},
+		{name: "transport", kind: provider.ErrorKindTransport, retryable: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			clientFactory := (&fakeclient.ProjectClient{
+				GetFunc: func(_ int, _ *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+					if tt.status == 0 {
+						return cherrygo.Project{}, nil, errors.New("no such host")
+					}
+					return cherrygo.Project{},
+						&cherrygo.Response{Response: &http.Response{StatusCode: tt.status}},
+						errors.New("Error: Error response from API: something went wrong (error code: 1)")
+				},
+			}).Factory()
+
+			p := provider.Project{GetClient: clientFactory, GetLogger: GetFakeLogger}
+
+			_, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{ID: "0"})
+
+			var apiErr *provider.APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.kind, apiErr.Kind)
+			assert.Equal(t, tt.retryable, apiErr.Retryable())
+			if tt.status != 0 {
+				assert.Equal(t, tt.status, apiErr.StatusCode)
+				assert.Equal(t, "something went wrong", apiErr.Message)
+			}
+		})
+	}
+}
+
 func TestDiffProjectRequiresReplace(t *testing.T) {
 	p := provider.Project{}
 
@@ -148,6 +170,7 @@ func TestDiffProjectRequiresReplace(t *testing.T) {
 	})
 
 	assert.Equal(t, prov.PropertyDiff{Kind: prov.UpdateReplace}, resp.DetailedDiff["team"])
+	assert.False(t, resp.DeleteBeforeReplace, "the replacement should be created first")
 	assert.NoError(t, err)
 }
 
@@ -171,7 +194,9 @@ func TestCreateProject(t *testing.T) {
 					Team: 1,
 				}, LocalASN: 0},
 			},
-			clientFactory: newFakeProjectsClientFactory(withCreateProject(projectCreateOK)),
+			clientFactory: (&fakeclient.ProjectClient{
+				CreateFunc: projectCreateOK,
+			}).Factory(),
 		},
 		{
 			name: "ok",
@@ -186,7 +211,9 @@ func TestCreateProject(t *testing.T) {
 					Team: 1,
 				}, LocalASN: 0},
 			},
-			clientFactory: newFakeProjectsClientFactory(withCreateProject(projectCreateOK)),
+			clientFactory: (&fakeclient.ProjectClient{
+				CreateFunc: projectCreateOK,
+			}).Factory(),
 		},
 	}
 
@@ -200,3 +227,317 @@ func TestCreateProject(t *testing.T) {
 	}
 
 }
+
+func TestReadProjectImport(t *testing.T) {
+	clientFactory := (&fakeclient.ProjectClient{
+		GetFunc: func(projectID int, _ *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+			return cherrygo.Project{
+				ID:   projectID,
+				Name: "imported",
+				Bgp:  cherrygo.ProjectBGP{Enabled: true, LocalASN: 65000},
+			}, nil, nil
+		},
+		ListFunc: func(teamID int, _ *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+			if teamID == 2 {
+				return []cherrygo.Project{{ID: 10}}, nil, nil
+			}
+			return []cherrygo.Project{{ID: 11}}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.Project{
+		GetClient:     clientFactory,
+		GetTeamClient: newFakeTeamClientFactory(cherrygo.Team{ID: 1}, cherrygo.Team{ID: 2}),
+		GetLogger:     GetFakeLogger,
+	}
+
+	// No inputs or state are known during import.
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{ID: "10"})
+	require.NoError(t, err)
+
+	args := provider.ProjectArgs{Name: "imported", Team: 2, BGP: true}
+	assert.Equal(t, infer.ReadResponse[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "10",
+		Inputs: args,
+		State:  provider.ProjectState{ProjectArgs: args, LocalASN: 65000},
+	}, resp)
+}
+
+func TestReadProjectNoTeam(t *testing.T) {
+	clientFactory := (&fakeclient.ProjectClient{
+		GetFunc: func(projectID int, _ *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+			return cherrygo.Project{ID: projectID}, nil, nil
+		},
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+			return nil, nil, nil
+		},
+	}).Factory()
+
+	p := provider.Project{
+		GetClient:     clientFactory,
+		GetTeamClient: newFakeTeamClientFactory(cherrygo.Team{ID: 1}),
+		GetLogger:     GetFakeLogger,
+	}
+
+	_, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{ID: "10"})
+	require.Error(t, err)
+}
+
+// newProjectFakes returns a project resource with fakes of all its clients, backed by a fake API.
+func newProjectFakes(t *testing.T) (*fakeapi.Server, *fakeclient.Clients, provider.Project) {
+	t.Helper()
+
+	api := fakeapi.New()
+	t.Cleanup(api.Close)
+	clients, err := fakeclient.New(api)
+	require.NoError(t, err)
+
+	return api, clients, provider.Project{
+		GetClient:        clients.Projects.Factory(),
+		GetTeamClient:    clients.Teams.Factory(),
+		GetServerClient:  clients.Servers.Factory(),
+		GetIPClient:      clients.IPs.Factory(),
+		GetStorageClient: clients.Storages.Factory(),
+		GetLogger:        GetFakeLogger,
+	}
+}
+
+func TestReadProjectChecksOnlyTheTeam(t *testing.T) {
+	api, clients, p := newProjectFakes(t)
+	api.AddTeam("other")
+	team := api.AddTeam("test")
+	id := api.AddProject(team, "test")
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     strconv.Itoa(id),
+		Inputs: provider.ProjectArgs{Name: "test", Team: team},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, team, resp.State.Team)
+
+	assert.Empty(t, clients.Teams.CallsTo("List"), "teams must only be searched if the project isn't in the team")
+	assert.Len(t, clients.Projects.CallsTo("List"), 1)
+}
+
+func TestReadProjectMovedTeam(t *testing.T) {
+	api, clients, p := newProjectFakes(t)
+	old := api.AddTeam("old")
+	team := api.AddTeam("new")
+	id := api.AddProject(team, "test")
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     strconv.Itoa(id),
+		Inputs: provider.ProjectArgs{Name: "test", Team: old},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, team, resp.State.Team)
+
+	// The hint team is only listed once.
+	var listed []any
+	for _, call := range clients.Projects.CallsTo("List") {
+		listed = append(listed, call.Args[0])
+	}
+	assert.Equal(t, []any{old, team}, listed)
+}
+
+func TestReadProjectImportPaginated(t *testing.T) {
+	api, clients, p := newProjectFakes(t)
+	team := api.AddTeam("test")
+	for i := range 150 {
+		api.AddProject(team, fmt.Sprintf("test-%d", i))
+	}
+	id := api.AddProject(team, "imported")
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID: strconv.Itoa(id),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, team, resp.State.Team)
+	assert.Len(t, clients.Projects.CallsTo("List"), 2)
+}
+
+func TestReadProjectDrift(t *testing.T) {
+	config := provider.ProjectArgs{Name: "test", Team: 1, BGP: false}
+
+	// Renamed and BGP enabled in the console.
+	clientFactory := (&fakeclient.ProjectClient{
+		GetFunc: func(projectID int, _ *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+			return cherrygo.Project{
+				ID:   projectID,
+				Name: "renamed",
+				Bgp:  cherrygo.ProjectBGP{Enabled: true, LocalASN: 65000},
+			}, nil, nil
+		},
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+			return []cherrygo.Project{{ID: 10}}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.Project{
+		GetClient:     clientFactory,
+		GetTeamClient: newFakeTeamClientFactory(cherrygo.Team{ID: 1}),
+		GetLogger:     GetFakeLogger,
+	}
+
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "10",
+		Inputs: config,
+		State:  provider.ProjectState{ProjectArgs: config},
+	})
+	require.NoError(t, err)
+
+	live := provider.ProjectArgs{Name: "renamed", Team: 1, BGP: true}
+	assert.Equal(t, live, resp.Inputs)
+	assert.Equal(t, live, resp.State.ProjectArgs)
+
+	// The next update must bring the project back in line with the program.
+	diff, err := p.Diff(t.Context(), infer.DiffRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "10",
+		Inputs: config,
+		State:  resp.State,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]prov.PropertyDiff{
+		"name": {Kind: prov.Update},
+		"bgp":  {Kind: prov.Update},
+	}, diff.DetailedDiff)
+}
+
+func TestDeletionProtectionProject(t *testing.T) {
+	// No client callbacks, the API must not be called.
+	p := provider.Project{GetClient: (&fakeclient.ProjectClient{}).Factory(), GetLogger: GetFakeLogger}
+
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.ProjectState]{
+		ID:    "1",
+		State: provider.ProjectState{ProjectArgs: provider.ProjectArgs{Team: 1, DeletionProtection: true}},
+	})
+	require.ErrorContains(t, err, "deletion protection")
+
+	protected := provider.ProjectState{ProjectArgs: provider.ProjectArgs{
+		Name: "test", Team: 1, DeletionProtection: true,
+	}}
+
+	_, err = p.Diff(t.Context(), infer.DiffRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "1",
+		Inputs: provider.ProjectArgs{Name: "test", Team: 2, DeletionProtection: true},
+		State:  protected,
+	})
+	require.ErrorContains(t, err, "deletion protection", "replacement must be refused")
+
+	// Turning the protection off in the same update doesn't allow replacing.
+	_, err = p.Diff(t.Context(), infer.DiffRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "1",
+		Inputs: provider.ProjectArgs{Name: "test", Team: 2},
+		State:  protected,
+	})
+	require.ErrorContains(t, err, "deletion protection")
+
+	resp, err := p.Diff(t.Context(), infer.DiffRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "1",
+		Inputs: provider.ProjectArgs{Name: "renamed", Team: 1, DeletionProtection: true},
+		State:  protected,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prov.PropertyDiff{Kind: prov.Update}, resp.DetailedDiff["name"])
+}
+
+func TestReadProjectKeepsDeletionProtection(t *testing.T) {
+	clientFactory := (&fakeclient.ProjectClient{
+		GetFunc: func(projectID int, _ *cherrygo.GetOptions) (cherrygo.Project, *cherrygo.Response, error) {
+			return cherrygo.Project{ID: projectID, Name: "test"}, nil, nil
+		},
+		ListFunc: func(_ int, _ *cherrygo.GetOptions) ([]cherrygo.Project, *cherrygo.Response, error) {
+			return []cherrygo.Project{{ID: 10}}, nil, nil
+		},
+	}).Factory()
+
+	p := provider.Project{
+		GetClient:     clientFactory,
+		GetTeamClient: newFakeTeamClientFactory(cherrygo.Team{ID: 1}),
+		GetLogger:     GetFakeLogger,
+	}
+
+	args := provider.ProjectArgs{Name: "test", Team: 1, DeletionProtection: true}
+	resp, err := p.Read(t.Context(), infer.ReadRequest[provider.ProjectArgs, provider.ProjectState]{
+		ID:     "10",
+		Inputs: args,
+		State:  provider.ProjectState{ProjectArgs: args},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Inputs.DeletionProtection)
+	assert.True(t, resp.State.DeletionProtection)
+}
+
+// addProjectContents adds a server to the project, with a floating IP address targeted to it
+// and a volume attached to it.
+func addProjectContents(t *testing.T, api *fakeapi.Server, clients *fakeclient.Clients, project int) {
+	t.Helper()
+
+	server := api.AddServer(project, "LT-Siauliai", "B1-1-1gb-20s-shared")
+	ip := api.AddIP(project, "LT-Siauliai")
+	_, _, err := clients.IPs.Default.Assign(ip, &cherrygo.AssignIPAddress{ServerID: server})
+	require.NoError(t, err)
+	api.AddVolume(project, server, "data")
+}
+
+// methods returns the methods of the calls, in order.
+func methods(calls []fakeclient.Call) []string {
+	names := make([]string, 0, len(calls))
+	for _, call := range calls {
+		names = append(names, call.Method)
+	}
+	return names
+}
+
+func TestDeleteProjectNotEmpty(t *testing.T) {
+	api, clients, p := newProjectFakes(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+	addProjectContents(t, api, clients, project)
+
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.ProjectState]{
+		ID:    strconv.Itoa(project),
+		State: provider.ProjectState{ProjectArgs: provider.ProjectArgs{Team: 1}},
+	})
+
+	server, volume := api.Servers()[0], api.Volumes()[0]
+	ip := api.IPAddresses()[slices.IndexFunc(api.IPAddresses(), func(ip cherrygo.IPAddress) bool {
+		return ip.Type == fakeapi.FloatingIP
+	})]
+	require.EqualError(t, err, fmt.Sprintf("project %d isn't empty, delete its contents or enable forceDestroy first: "+
+		"servers: %d (%s); ip addresses: %s (%s); volumes: %d (%s)",
+		project, server.ID, server.Hostname, ip.ID, ip.Address, volume.ID, volume.Name))
+
+	// Nothing is changed.
+	assert.Equal(t, []string{"List"}, methods(clients.Servers.Calls()))
+	assert.Equal(t, []string{"List"}, methods(clients.IPs.Calls()))
+	assert.Equal(t, []string{"List"}, methods(clients.Storages.Calls()))
+	assert.Empty(t, clients.Projects.Calls())
+}
+
+func TestDeleteProjectForceDestroy(t *testing.T) {
+	api, clients, p := newProjectFakes(t)
+	project := api.AddProject(api.AddTeam("test"), "test")
+	addProjectContents(t, api, clients, project)
+
+	// What's attached to the server goes first.
+	clients.Servers.DeleteFunc = func(serverID int) (cherrygo.Server, *cherrygo.Response, error) {
+		assert.Empty(t, api.Volumes(), "volumes must be deleted before the server")
+		assert.Len(t, api.IPAddresses(), 1, "only the server's own address must be left")
+		return clients.Servers.Default.Delete(serverID)
+	}
+
+	_, err := p.Delete(t.Context(), infer.DeleteRequest[provider.ProjectState]{
+		ID:    strconv.Itoa(project),
+		State: provider.ProjectState{ProjectArgs: provider.ProjectArgs{Team: 1, ForceDestroy: true}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"List", "Unassign", "Remove"}, methods(clients.IPs.Calls()))
+	assert.Equal(t, []string{"List", "Detach", "Delete"}, methods(clients.Storages.Calls()))
+	assert.Len(t, clients.Servers.CallsTo("Delete"), 1)
+
+	// The server's own address goes with it.
+	assert.Empty(t, api.IPAddresses())
+	assert.Empty(t, api.Servers())
+	assert.Empty(t, api.Projects())
+}
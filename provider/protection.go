@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"fmt"
+
+	prov "github.com/pulumi/pulumi-go-provider"
+)
+
+// deletionProtectedError is returned when deleting or replacing a protected resource.
+func deletionProtectedError(resource, id string) error {
+	return fmt.Errorf("%s %s has deletion protection enabled, "+
+		"set deletionProtection to false and run an update before deleting or replacing it", resource, id)
+}
+
+// checkReplaceProtection fails if the diff replaces a resource that had deletion protection
+// enabled. The protection is read from the old state, so turning it off in the same update
+// that replaces the resource isn't enough.
+func checkReplaceProtection(diff map[string]prov.PropertyDiff, protected bool, resource, id string) error {
+	if !protected {
+		return nil
+	}
+
+	for _, d := range diff {
+		switch d.Kind {
+		case prov.AddReplace, prov.UpdateReplace, prov.DeleteReplace:
+			return deletionProtectedError(resource, id)
+		case prov.Add, prov.Update, prov.Delete, prov.Stable:
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"net/http"
 	"os"
 
 	"github.com/cherryservers/cherrygo/v3"
@@ -16,24 +17,109 @@ var Version string //nolint:gochecknoglobals // Injecting the version
 
 const Name = "pulumi-cherry-servers"
 
+// apiURLEnv overrides the API endpoint, e.g. to point the provider at a fake API in tests.
+const apiURLEnv = "CHERRY_API_URL"
+
 type Config struct {
-	Token string `pulumi:"token" provider:"secret"`
+	Token             string  `pulumi:"token"                      provider:"secret"`
+	RequestsPerSecond float64 `pulumi:"requestsPerSecond,optional"`
+	Burst             int     `pulumi:"burst,optional"`
+	AuditLog          string  `pulumi:"auditLog,optional"`
+
+	limiter *rateLimiter
+	audit   *auditLog
 }
 
 func (c *Config) Annotate(a infer.Annotator) {
 	a.Describe(&c.Token, "Cherry Servers API token.")
+	a.Describe(&c.RequestsPerSecond,
+		"Maximum average number of API requests per second, shared by all resource operations. "+
+			"A non-positive value disables rate limiting.")
+	a.Describe(&c.Burst, "Maximum number of API requests that can be made at once, before rate limiting kicks in.")
+	a.Describe(&c.AuditLog, "Path of a file to append a JSON line to for every API call that changes anything. "+
+		"Can also be set with the "+auditLogEnv+" environment variable. Disabled if empty.")
+	a.SetDefault(&c.RequestsPerSecond, defaultRequestsPerSecond)
+	a.SetDefault(&c.Burst, defaultBurst)
+}
+
+// Configure builds the process-wide state shared by all resources.
+func (c *Config) Configure(_ context.Context) error {
+	c.limiter = newRateLimiter(c.RequestsPerSecond, c.Burst)
+
+	if path, ok := os.LookupEnv(auditLogEnv); ok {
+		c.AuditLog = path
+	}
+
+	var err error
+	c.audit, err = openAuditLog(c.AuditLog)
+	return err
 }
 
-var _ infer.Annotated = (*Config)(nil)
+const (
+	defaultRequestsPerSecond = 5.0
+	defaultBurst             = 10
+)
+
+var (
+	_ infer.Annotated       = (*Config)(nil)
+	_ infer.CustomConfigure = (*Config)(nil)
+)
 
-func getProjectClient(ctx context.Context) (ProjectClient, error) {
+// newClient builds an API client, which routes every request
+// through the provider rate limiter, retries transient failures, traces each call
+// and records changes in the audit log. The transports log through getLogger.
+func newClient(ctx context.Context, getLogger GetLoggerFunc) (*cherrygo.Client, error) {
 	cfg := infer.GetConfig[Config](ctx)
 
 	if token, ok := os.LookupEnv("CHERRY_AUTH_TOKEN"); ok {
 		cfg.Token = token
 	}
 
-	client, err := cherrygo.NewClient(cherrygo.WithAuthToken(cfg.Token))
+	jitter, err := jitterFromInterval(0, retryMaxJitter)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := getLogger(ctx)
+
+	transport := tracingTransport{
+		ctx: ctx,
+		next: auditTransport{
+			ctx:    ctx,
+			log:    cfg.audit,
+			logger: logger,
+			next: retryTransport{
+				ctx:      ctx,
+				logger:   logger,
+				attempts: maxRequestAttempts,
+				backoff:  exponentialBackoff(retryBaseDelay, jitter),
+				next: rateLimitedTransport{
+					limiter: cfg.limiter,
+					logger:  logger,
+					next:    http.DefaultTransport,
+				},
+			},
+		},
+	}
+
+	opts := []cherrygo.ClientOpt{
+		cherrygo.WithAuthToken(cfg.Token),
+		cherrygo.WithHTTPClient(&http.Client{Transport: transport}),
+	}
+	if url, ok := os.LookupEnv(apiURLEnv); ok {
+		opts = append(opts, cherrygo.WithURL(url))
+	}
+
+	return cherrygo.NewClient(opts...)
+}
+
+// clientFactories build the API clients of the resources, logging through getLogger.
+type clientFactories struct {
+	getLogger GetLoggerFunc
+}
+
+func (f clientFactories) project(ctx context.Context) (ProjectClient, error) {
+	client, err := newClient(ctx, f.getLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -41,29 +127,87 @@ func getProjectClient(ctx context.Context) (ProjectClient, error) {
 	return client.Projects, nil
 }
 
-func getIPClient(cfg Config) (IPClient, error) {
-	if token, ok := os.LookupEnv("CHERRY_AUTH_TOKEN"); ok {
-		cfg.Token = token
+func (f clientFactories) ip(ctx context.Context) (IPClient, error) {
+	client, err := newClient(ctx, f.getLogger)
+	if err != nil {
+		return nil, err
 	}
 
-	client, err := cherrygo.NewClient(cherrygo.WithAuthToken(cfg.Token))
+	return NewIPClient(client), nil
+}
+
+func (f clientFactories) team(ctx context.Context) (TeamClient, error) {
+	client, err := newClient(ctx, f.getLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Teams, nil
+}
+
+func (f clientFactories) region(ctx context.Context) (RegionClient, error) {
+	client, err := newClient(ctx, f.getLogger)
 	if err != nil {
 		return nil, err
 	}
 
-	return client.IPAddresses, nil
+	return client.Regions, nil
 }
 
-var _ ProjectClientFactory = getProjectClient
+func (f clientFactories) server(ctx context.Context) (ServerClient, error) {
+	client, err := newClient(ctx, f.getLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Servers, nil
+}
+
+func (f clientFactories) storage(ctx context.Context) (StorageClient, error) {
+	client, err := newClient(ctx, f.getLogger)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Storages, nil
+}
+
+var (
+	_ ProjectClientFactory = clientFactories{}.project
+	_ TeamClientFactory    = clientFactories{}.team
+	_ IPClientFactory      = clientFactories{}.ip
+	_ RegionClientFactory  = clientFactories{}.region
+	_ ServerClientFactory  = clientFactories{}.server
+	_ StorageClientFactory = clientFactories{}.storage
+)
 
 func Provider() (p.Provider, error) {
-	return infer.NewProviderBuilder().
+	return NewProvider(GetLogger)
+}
+
+// NewProvider builds the provider, with its resources and API clients logging through getLogger.
+func NewProvider(getLogger GetLoggerFunc) (p.Provider, error) {
+	clients := clientFactories{getLogger: getLogger}
+
+	prov, err := infer.NewProviderBuilder().
 		WithResources(
-			infer.Resource(&Project{GetClient: getProjectClient, GetLogger: GetLogger}),
-			infer.Resource(&IP{getIPClient}),
+			infer.Resource(&Project{
+				GetClient:        clients.project,
+				GetTeamClient:    clients.team,
+				GetServerClient:  clients.server,
+				GetIPClient:      clients.ip,
+				GetStorageClient: clients.storage,
+				GetLogger:        getLogger,
+			}),
+			infer.Resource(&IP{GetClient: clients.ip, GetRegionClient: clients.region, GetLogger: getLogger}),
 		).
 		WithDisplayName(Name).
 		WithNamespace("caliban0").
 		WithConfig(infer.Config(&Config{})).
 		Build()
+	if err != nil {
+		return p.Provider{}, err
+	}
+
+	return withTracing(withAudit(prov)), nil
 }
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiter is a token bucket shared by every API client in the provider process.
+// A nil *rateLimiter doesn't limit anything.
+type rateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// newRateLimiter returns a limiter that allows rps requests per second on average,
+// with bursts of up to burst requests. A non-positive rps disables limiting.
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if rps <= 0 {
+		return nil
+	}
+
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+// wait blocks until a request is allowed to proceed or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, logger Logger) error {
+	if l == nil {
+		return nil
+	}
+
+	r := l.limiter.Reserve()
+	if !r.OK() {
+		return fmt.Errorf("rate limiter burst %d can't fit a single request", l.limiter.Burst())
+	}
+
+	delay := r.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	logger.Debugf("API request rate limit reached, throttling request for %v", delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return fmt.Errorf("context cancelled while waiting for rate limiter: %w", ctx.Err())
+	}
+}
+
+// rateLimitedTransport makes every request wait for the limiter first.
+// The wait ends early if the request's context is done, which the outer transports
+// derive from the resource operation, as cherrygo doesn't take a context.
+type rateLimitedTransport struct {
+	limiter *rateLimiter
+	logger  Logger
+	next    http.RoundTripper
+}
+
+func (t rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context(), t.logger); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+var _ http.RoundTripper = rateLimitedTransport{}
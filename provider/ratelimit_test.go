@@ -0,0 +1,113 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterDisabled(t *testing.T) {
+	limiter := provider.NewRateLimiter(0, 10)
+	require.Nil(t, limiter)
+
+	logger := &RecordingLogger{}
+	for range 100 {
+		require.NoError(t, limiter.Wait(t.Context(), logger))
+	}
+	assert.Empty(t, logger.Messages())
+}
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := provider.NewRateLimiter(1, 3)
+	logger := &RecordingLogger{}
+
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, limiter.Wait(t.Context(), logger))
+	}
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "a burst mustn't be throttled")
+	assert.Empty(t, logger.Messages())
+}
+
+func TestRateLimiterDelaysOverBurst(t *testing.T) {
+	const rps = 10
+	limiter := provider.NewRateLimiter(rps, 1)
+	logger := &RecordingLogger{}
+
+	require.NoError(t, limiter.Wait(t.Context(), logger))
+
+	start := time.Now()
+	require.NoError(t, limiter.Wait(t.Context(), logger))
+
+	// Allow for the time the first request took.
+	assert.GreaterOrEqual(t, time.Since(start), time.Second/rps-10*time.Millisecond)
+
+	messages := logger.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "debug", messages[0].Level, "throttling is routine, so it mustn't flood the console")
+	assert.Contains(t, messages[0].Text, "throttling request")
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	limiter := provider.NewRateLimiter(0.1, 1)
+	require.NoError(t, limiter.Wait(t.Context(), FakeLogger{}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := limiter.Wait(ctx, FakeLogger{})
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second, "the wait must end when the context is cancelled")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+type ctxKey struct{}
+
+func TestRateLimitedTransportKeepsRequestContext(t *testing.T) {
+	var got any
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Context().Value(ctxKey{})
+		return stubResponse(http.StatusOK), nil
+	})
+	transport := provider.NewRateLimitedTransport(provider.NewRateLimiter(10, 1), FakeLogger{}, next)
+
+	// e.g. the span of the API call, added by the tracing transport.
+	ctx := context.WithValue(t.Context(), ctxKey{}, "span")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1/ips/1", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Equal(t, "span", got)
+}
+
+func TestRateLimitedTransportCancelledRequest(t *testing.T) {
+	next := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return stubResponse(http.StatusOK), nil
+	})
+	transport := provider.NewRateLimitedTransport(provider.NewRateLimiter(0.1, 1), FakeLogger{}, next)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.com/v1/ips/1", nil)
+	require.NoError(t, err)
+	_, err = transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1/ips/1", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	require.ErrorIs(t, err, context.Canceled, "the wait must end when the request is cancelled")
+}
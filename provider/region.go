@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/cherryservers/cherrygo/v3"
+)
+
+type RegionClient interface {
+	cherrygo.RegionsService
+}
+
+type RegionClientFactory func(ctx context.Context) (RegionClient, error)
+
+// regionCache remembers the region slugs offered by the API,
+// so that checking many resources doesn't list them every time.
+// The zero value is ready to use.
+type regionCache struct {
+	mu    sync.Mutex
+	slugs []string
+}
+
+// get returns the known region slugs, listing them on first use.
+// Failed listings aren't cached.
+func (c *regionCache) get(ctx context.Context, getClient RegionClientFactory) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.slugs != nil {
+		return c.slugs, nil
+	}
+
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	regions, r, err := client.List(nil)
+	if err = apiError(r, err); err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+
+	slugs := make([]string, 0, len(regions))
+	for _, region := range regions {
+		slugs = append(slugs, region.Slug)
+	}
+	slices.Sort(slugs)
+
+	c.slugs = slugs
+	return slugs, nil
+}
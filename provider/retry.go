@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	maxRequestAttempts = 4
+	retryBaseDelay     = time.Second
+	retryMaxJitter     = time.Second / 2
+)
+
+// retryTransport repeats requests that failed in a way that might go away on its own.
+// Rate limited requests were rejected outright, so they're always repeated.
+// Server and transport errors leave the outcome unknown, so only idempotent
+// requests are repeated after them.
+type retryTransport struct {
+	ctx      context.Context
+	logger   Logger
+	attempts int
+	backoff  func(attempt int) time.Duration
+	next     http.RoundTripper
+}
+
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+
+		apiErr := retryableError(req.Method, resp, err)
+		if apiErr == nil || attempt >= t.attempts || t.ctx.Err() != nil || !canRewind(req) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			delay = max(delay, retryAfter(resp))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		t.logger.Warningf("%s %s failed with %s, retrying in %v (attempt %d of %d)",
+			req.Method, req.URL.Path, apiErr.Kind, delay, attempt, t.attempts)
+		recordRetry(req.Context(), attempt)
+
+		if err = sleep(t.ctx, delay); err != nil {
+			return nil, err
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+var _ http.RoundTripper = retryTransport{}
+
+// retryableError returns the classified failure of a round trip,
+// or nil if the request shouldn't be repeated.
+func retryableError(method string, resp *http.Response, err error) *APIError {
+	var apiErr *APIError
+
+	switch {
+	case err != nil:
+		apiErr = &APIError{Kind: ErrorKindTransport, Err: err}
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		apiErr = &APIError{Kind: errorKindFromStatus(resp.StatusCode), StatusCode: resp.StatusCode}
+	default:
+		return nil
+	}
+
+	if !apiErr.Retryable() {
+		return nil
+	}
+
+	if apiErr.Kind != ErrorKindRateLimited && !isIdempotent(method) {
+		return nil
+	}
+
+	return apiErr
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header, if any.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func canRewind(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of req with a fresh body, so that it can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = body
+	return r, nil
+}
+
+// exponentialBackoff doubles the delay on each attempt, starting at base,
+// and adds some jitter, so that concurrent operations don't retry in lockstep.
+func exponentialBackoff(base time.Duration, jitter jitterFunc) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		return base<<(attempt-1) + jitter()
+	}
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("context cancelled while waiting to retry: %w", ctx.Err())
+	}
+}
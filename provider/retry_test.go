@@ -0,0 +1,191 @@
+package provider_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubTransport answers requests with canned responses, in order, and keeps the bodies it was sent.
+type stubTransport struct {
+	responses []*http.Response
+	bodies    []string
+}
+
+func (s *stubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	s.bodies = append(s.bodies, string(body))
+
+	if len(s.bodies) > len(s.responses) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return s.responses[len(s.bodies)-1], nil
+}
+
+func stubResponse(status int, header ...string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+	for i := 0; i+1 < len(header); i += 2 {
+		resp.Header.Set(header[i], header[i+1])
+	}
+	return resp
+}
+
+func noBackoff(int) time.Duration { return 0 }
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		statuses   []int
+		wantCalls  int
+		wantStatus int
+	}{
+		{
+			name:       "GET retried on server error",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			wantCalls:  3,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "DELETE retried on server error",
+			method:     http.MethodDelete,
+			statuses:   []int{http.StatusServiceUnavailable, http.StatusNoContent},
+			wantCalls:  2,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "POST not retried on server error",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusInternalServerError, http.StatusCreated},
+			wantCalls:  1,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "POST retried when rate limited",
+			method:     http.MethodPost,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusCreated},
+			wantCalls:  2,
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "client error not retried",
+			method:     http.MethodGet,
+			statuses:   []int{http.StatusNotFound, http.StatusOK},
+			wantCalls:  1,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "attempt limit",
+			method: http.MethodGet,
+			statuses: []int{
+				http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+				http.StatusServiceUnavailable, http.StatusOK,
+			},
+			wantCalls:  3,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := &stubTransport{}
+			for _, status := range tt.statuses {
+				stub.responses = append(stub.responses, stubResponse(status))
+			}
+			transport := provider.NewRetryTransport(t.Context(), FakeLogger{}, 3, noBackoff, stub)
+
+			req, err := http.NewRequestWithContext(t.Context(), tt.method, "https://api.example.com/v1/ips/1", nil)
+			require.NoError(t, err)
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Len(t, stub.bodies, tt.wantCalls)
+		})
+	}
+}
+
+func TestRetryTransportResendsBody(t *testing.T) {
+	const body = `{"region":"LT-Siauliai","tags":{"env":"test"}}`
+
+	stub := &stubTransport{responses: []*http.Response{
+		stubResponse(http.StatusTooManyRequests),
+		stubResponse(http.StatusTooManyRequests),
+		stubResponse(http.StatusCreated),
+	}}
+	transport := provider.NewRetryTransport(t.Context(), FakeLogger{}, 3, noBackoff, stub)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost,
+		"https://api.example.com/v1/projects/1/ips", bytes.NewReader([]byte(body)))
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{body, body, body}, stub.bodies)
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	const retryAfter = 1
+
+	stub := &stubTransport{responses: []*http.Response{
+		stubResponse(http.StatusTooManyRequests, "Retry-After", strconv.Itoa(retryAfter)),
+		stubResponse(http.StatusOK),
+	}}
+	logger := &RecordingLogger{}
+	transport := provider.NewRetryTransport(t.Context(), logger, 3, noBackoff, stub)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "https://api.example.com/v1/ips/1", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), retryAfter*time.Second)
+
+	messages := logger.Messages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0].Text, "retrying in 1s (attempt 1 of 3)")
+}
+
+func TestRetryTransportCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	stub := &stubTransport{responses: []*http.Response{
+		stubResponse(http.StatusServiceUnavailable),
+		stubResponse(http.StatusOK),
+	}}
+	backoff := func(int) time.Duration { return time.Hour }
+	transport := provider.NewRetryTransport(ctx, FakeLogger{}, 3, backoff, stub)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1/ips/1", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = transport.RoundTrip(req)
+
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Len(t, stub.bodies, 1, "the request mustn't be repeated once cancelled")
+}
@@ -0,0 +1,77 @@
+package provider_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/caliban0/pulumi-cherry-servers/provider"
+	"github.com/pmezard/go-difflib/difflib"
+	p "github.com/pulumi/pulumi-go-provider"
+	"github.com/pulumi/pulumi/pkg/v3/codegen/schema"
+	"github.com/stretchr/testify/require"
+)
+
+var updateSchema = flag.Bool("update-schema", false, //nolint:gochecknoglobals // Test flag.
+	"regenerate the checked-in schema.json from the provider")
+
+const schemaPath = "cmd/pulumi-cherry-servers/schema.json"
+
+// TestSchemaUpToDate checks that the checked-in schema, which the SDKs are generated from,
+// matches the provider. Run it with -update-schema to regenerate the schema,
+// and then `make codegen` to regenerate the SDKs.
+func TestSchemaUpToDate(t *testing.T) {
+	got := providerSchema(t)
+
+	if *updateSchema {
+		require.NoError(t, os.WriteFile(schemaPath, got, 0o600))
+		return
+	}
+
+	want, err := os.ReadFile(schemaPath)
+	require.NoError(t, err)
+
+	if bytes.Equal(got, want) {
+		return
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(want)),
+		B:        difflib.SplitLines(string(got)),
+		FromFile: schemaPath,
+		ToFile:   "provider",
+		Context:  3,
+	})
+	require.NoError(t, err)
+	t.Errorf("%s is out of date, run `go test ./provider -run TestSchemaUpToDate -update-schema`:\n%s",
+		schemaPath, diff)
+}
+
+// providerSchema gets the schema over gRPC from an in-process provider, and formats it
+// the way `pulumi package get-schema | jq 'del(.version)'` does in the Makefile.
+func providerSchema(t *testing.T) []byte {
+	t.Helper()
+
+	prov, err := provider.Provider()
+	require.NoError(t, err)
+
+	spec, err := p.GetSchema(t.Context(), provider.Name, "", prov)
+	require.NoError(t, err)
+
+	// Binding the schema normalizes it, as the Pulumi CLI does.
+	pkg, err := schema.ImportSpec(spec, nil, schema.ValidationOptions{})
+	require.NoError(t, err)
+	bound, err := pkg.MarshalSpec()
+	require.NoError(t, err)
+	bound.Version = ""
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	require.NoError(t, enc.Encode(bound))
+
+	return buf.Bytes()
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"maps"
+	"strings"
+)
+
+// reservedTagPrefix is the namespace of tags managed by the platform, not the user.
+const reservedTagPrefix = "cherryservers:"
+
+// isReservedTag reports whether the tag is managed by the platform or the provider itself.
+// Reserved tags can't be set in inputs and are left out of the state.
+func isReservedTag(key string) bool {
+	return key == retainedTag || isPlatformTag(key)
+}
+
+// isPlatformTag reports whether the tag is managed by the platform.
+func isPlatformTag(key string) bool {
+	return strings.HasPrefix(strings.ToLower(key), reservedTagPrefix)
+}
+
+// normalizeTags returns tags in their canonical form: without reserved tags, and nil if empty.
+// The API might not keep the case of keys, so keys that match one in known,
+// ignoring case, are spelled the way they are in known.
+func normalizeTags(tags, known map[string]string) map[string]string {
+	var normalized map[string]string
+
+	for k, v := range tags {
+		if isReservedTag(k) {
+			continue
+		}
+
+		for knownKey := range known {
+			if strings.EqualFold(k, knownKey) {
+				k = knownKey
+				break
+			}
+		}
+
+		if normalized == nil {
+			normalized = map[string]string{}
+		}
+		normalized[k] = v
+	}
+
+	return normalized
+}
+
+// tagsEqual compares tags, ignoring reserved tags, the case of keys,
+// and the difference between nil and empty.
+func tagsEqual(a, b map[string]string) bool {
+	return maps.Equal(foldTags(a), foldTags(b))
+}
+
+func foldTags(tags map[string]string) map[string]string {
+	folded := make(map[string]string, len(tags))
+	for k, v := range tags {
+		if !isReservedTag(k) {
+			folded[strings.ToLower(k)] = v
+		}
+	}
+	return folded
+}
+
+// tagsPayload returns tags for an API request. It's never nil,
+// so that an update without tags removes them, instead of leaving them as they are.
+// The API replaces all tags, so the platform tags in live, the tags the resource has now,
+// are sent back along with the user's.
+func tagsPayload(tags, live map[string]string) *map[string]string {
+	payload := map[string]string{}
+	for k, v := range live {
+		if isPlatformTag(k) {
+			payload[k] = v
+		}
+	}
+	for k, v := range tags {
+		if !isReservedTag(k) {
+			payload[k] = v
+		}
+	}
+	return &payload
+}
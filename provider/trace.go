@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	p "github.com/pulumi/pulumi-go-provider"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/caliban0/pulumi-cherry-servers/provider"
+
+// Span attributes that aren't covered by OpenTelemetry semantic conventions.
+const (
+	attrResourceType = attribute.Key("cherry.resource.type")
+	attrResourceID   = attribute.Key("cherry.resource.id")
+	attrRetryCount   = attribute.Key("cherry.retry_count")
+)
+
+// InitTracing exports spans over OTLP/HTTP, if an endpoint is set with
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT.
+// The exporter reads the rest of its settings from the standard OTEL_EXPORTER_OTLP_* variables.
+// Otherwise, spans aren't recorded at all.
+// The returned function flushes pending spans and has to be called before the process exits.
+func InitTracing(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return noop, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return noop, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName(Name),
+			semconv.ServiceVersion(Version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// endSpan records the outcome of an operation and ends its span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// withTracing wraps the resource operations of a provider in spans.
+// API calls made by an operation are traced as its children.
+func withTracing(prov p.Provider) p.Provider {
+	check, diff, create, read, update, del := prov.Check, prov.Diff, prov.Create, prov.Read, prov.Update, prov.Delete
+
+	if check != nil {
+		prov.Check = func(ctx context.Context, req p.CheckRequest) (p.CheckResponse, error) {
+			ctx, span := startResourceSpan(ctx, "Check", req.Urn.Type().String(), "")
+			resp, err := check(ctx, req)
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+
+	if diff != nil {
+		prov.Diff = func(ctx context.Context, req p.DiffRequest) (p.DiffResponse, error) {
+			ctx, span := startResourceSpan(ctx, "Diff", req.Urn.Type().String(), req.ID)
+			resp, err := diff(ctx, req)
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+
+	if create != nil {
+		prov.Create = func(ctx context.Context, req p.CreateRequest) (p.CreateResponse, error) {
+			ctx, span := startResourceSpan(ctx, "Create", req.Urn.Type().String(), "")
+			span.SetAttributes(attribute.Bool("cherry.dry_run", req.DryRun))
+			resp, err := create(ctx, req)
+			span.SetAttributes(attrResourceID.String(resp.ID))
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+
+	if read != nil {
+		prov.Read = func(ctx context.Context, req p.ReadRequest) (p.ReadResponse, error) {
+			ctx, span := startResourceSpan(ctx, "Read", req.Urn.Type().String(), req.ID)
+			resp, err := read(ctx, req)
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+
+	if update != nil {
+		prov.Update = func(ctx context.Context, req p.UpdateRequest) (p.UpdateResponse, error) {
+			ctx, span := startResourceSpan(ctx, "Update", req.Urn.Type().String(), req.ID)
+			span.SetAttributes(attribute.Bool("cherry.dry_run", req.DryRun))
+			resp, err := update(ctx, req)
+			endSpan(span, err)
+			return resp, err
+		}
+	}
+
+	if del != nil {
+		prov.Delete = func(ctx context.Context, req p.DeleteRequest) error {
+			ctx, span := startResourceSpan(ctx, "Delete", req.Urn.Type().String(), req.ID)
+			err := del(ctx, req)
+			endSpan(span, err)
+			return err
+		}
+	}
+
+	return prov
+}
+
+func startResourceSpan(ctx context.Context, operation, resourceType, id string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{attrResourceType.String(resourceType)}
+	if id != "" {
+		attrs = append(attrs, attrResourceID.String(id))
+	}
+
+	return tracer().Start(ctx, resourceType+" "+operation, //nolint:spancheck // Ended by the caller.
+		trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
+
+// tracingTransport wraps every API call in a span, including its retries.
+// The span is a child of the resource operation in ctx, as cherrygo doesn't take contexts.
+type tracingTransport struct {
+	ctx  context.Context
+	next http.RoundTripper
+}
+
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(t.ctx, req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+			attrRetryCount.Int(0),
+		))
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp.StatusCode >= http.StatusBadRequest:
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	if resp != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+
+	span.End()
+	return resp, err
+}
+
+var _ http.RoundTripper = tracingTransport{}
+
+// recordRetry counts a repeated request on the API call span in ctx.
+func recordRetry(ctx context.Context, attempt int) {
+	trace.SpanFromContext(ctx).SetAttributes(attrRetryCount.Int(attempt))
+}
@@ -32,6 +32,36 @@ namespace Caliban0.PulumiCherryServers
 
         private static readonly global::Pulumi.Config __config = new global::Pulumi.Config("pulumi-cherry-servers");
 
+        private static readonly __Value<string?> _auditLog = new __Value<string?>(() => __config.Get("auditLog"));
+        /// <summary>
+        /// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        /// </summary>
+        public static string? AuditLog
+        {
+            get => _auditLog.Get();
+            set => _auditLog.Set(value);
+        }
+
+        private static readonly __Value<int?> _burst = new __Value<int?>(() => __config.GetInt32("burst") ?? 10);
+        /// <summary>
+        /// Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        /// </summary>
+        public static int? Burst
+        {
+            get => _burst.Get();
+            set => _burst.Set(value);
+        }
+
+        private static readonly __Value<double?> _requestsPerSecond = new __Value<double?>(() => __config.GetDouble("requestsPerSecond") ?? 5);
+        /// <summary>
+        /// Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+        /// </summary>
+        public static double? RequestsPerSecond
+        {
+            get => _requestsPerSecond.Get();
+            set => _requestsPerSecond.Set(value);
+        }
+
         private static readonly __Value<string?> _token = new __Value<string?>(() => __config.Get("token"));
         /// <summary>
         /// Cherry Servers API token.
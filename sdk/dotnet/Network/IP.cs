@@ -8,16 +8,16 @@ using System.Threading.Tasks;
 using Pulumi.Serialization;
 using Pulumi;
 
-namespace Caliban0.PulumiCherryServers.Provider
+namespace Caliban0.PulumiCherryServers.Network
 {
     /// <summary>
     /// Cherry Servers IP address.
     /// </summary>
-    [PulumiCherryServersResourceType("pulumi-cherry-servers:provider:IP")]
+    [PulumiCherryServersResourceType("pulumi-cherry-servers:network:IP")]
     public partial class IP : global::Pulumi.CustomResource
     {
         /// <summary>
-        /// IP address A record.
+        /// IP address A record. Removing it clears the record.
         /// </summary>
         [Output("aRecord")]
         public Output<string?> ARecord { get; private set; } = null!;
@@ -40,35 +40,56 @@ namespace Caliban0.PulumiCherryServers.Provider
         [Output("cidr")]
         public Output<string> Cidr { get; private set; } = null!;
 
+        /// <summary>
+        /// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        /// </summary>
+        [Output("deletionProtection")]
+        public Output<bool?> DeletionProtection { get; private set; } = null!;
+
+        /// <summary>
+        /// IP address project ID.
+        /// </summary>
         [Output("project")]
         public Output<int> Project { get; private set; } = null!;
 
         /// <summary>
-        /// IP address PTR record.
+        /// IP address PTR record. Removing it clears the record.
         /// </summary>
         [Output("ptrRecord")]
         public Output<string?> PtrRecord { get; private set; } = null!;
 
         /// <summary>
-        /// IP address project ID.
+        /// IP address region slug.
         /// </summary>
         [Output("region")]
         public Output<string> Region { get; private set; } = null!;
 
         /// <summary>
-        /// IP address that this address is routed to.
+        /// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        /// </summary>
+        [Output("retainOnDelete")]
+        public Output<bool?> RetainOnDelete { get; private set; } = null!;
+
+        /// <summary>
+        /// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
         /// </summary>
         [Output("routedTo")]
         public Output<string?> RoutedTo { get; private set; } = null!;
 
         /// <summary>
-        /// IP address tags.
+        /// Version of the state layout, used to upgrade state written by older provider versions.
+        /// </summary>
+        [Output("stateVersion")]
+        public Output<int?> StateVersion { get; private set; } = null!;
+
+        /// <summary>
+        /// IP address tags. Removing them clears all tags.
         /// </summary>
         [Output("tags")]
         public Output<ImmutableDictionary<string, string>?> Tags { get; private set; } = null!;
 
         /// <summary>
-        /// Server that this address is targeted to.
+        /// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         /// </summary>
         [Output("targetedTo")]
         public Output<int?> TargetedTo { get; private set; } = null!;
@@ -88,12 +109,12 @@ namespace Caliban0.PulumiCherryServers.Provider
         /// <param name="args">The arguments used to populate this resource's properties</param>
         /// <param name="options">A bag of options that control this resource's behavior</param>
         public IP(string name, IPArgs args, CustomResourceOptions? options = null)
-            : base("pulumi-cherry-servers:provider:IP", name, args ?? new IPArgs(), MakeResourceOptions(options, ""))
+            : base("pulumi-cherry-servers:network:IP", name, args ?? new IPArgs(), MakeResourceOptions(options, ""))
         {
         }
 
         private IP(string name, Input<string> id, CustomResourceOptions? options = null)
-            : base("pulumi-cherry-servers:provider:IP", name, null, MakeResourceOptions(options, id))
+            : base("pulumi-cherry-servers:network:IP", name, null, MakeResourceOptions(options, id))
         {
         }
 
@@ -102,6 +123,10 @@ namespace Caliban0.PulumiCherryServers.Provider
             var defaultOptions = new CustomResourceOptions
             {
                 Version = Utilities.Version,
+                Aliases =
+                {
+                    new global::Pulumi.Alias { Type = "pulumi-cherry-servers:provider:IP" },
+                },
             };
             var merged = CustomResourceOptions.Merge(defaultOptions, options);
             // Override the ID if one was specified for consistency with other language SDKs.
@@ -125,28 +150,43 @@ namespace Caliban0.PulumiCherryServers.Provider
     public sealed class IPArgs : global::Pulumi.ResourceArgs
     {
         /// <summary>
-        /// IP address A record.
+        /// IP address A record. Removing it clears the record.
         /// </summary>
         [Input("aRecord")]
         public Input<string>? ARecord { get; set; }
 
+        /// <summary>
+        /// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        /// </summary>
+        [Input("deletionProtection")]
+        public Input<bool>? DeletionProtection { get; set; }
+
+        /// <summary>
+        /// IP address project ID.
+        /// </summary>
         [Input("project", required: true)]
         public Input<int> Project { get; set; } = null!;
 
         /// <summary>
-        /// IP address PTR record.
+        /// IP address PTR record. Removing it clears the record.
         /// </summary>
         [Input("ptrRecord")]
         public Input<string>? PtrRecord { get; set; }
 
         /// <summary>
-        /// IP address project ID.
+        /// IP address region slug.
         /// </summary>
         [Input("region", required: true)]
         public Input<string> Region { get; set; } = null!;
 
         /// <summary>
-        /// IP address that this address is routed to.
+        /// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        /// </summary>
+        [Input("retainOnDelete")]
+        public Input<bool>? RetainOnDelete { get; set; }
+
+        /// <summary>
+        /// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
         /// </summary>
         [Input("routedTo")]
         public Input<string>? RoutedTo { get; set; }
@@ -155,7 +195,7 @@ namespace Caliban0.PulumiCherryServers.Provider
         private InputMap<string>? _tags;
 
         /// <summary>
-        /// IP address tags.
+        /// IP address tags. Removing them clears all tags.
         /// </summary>
         public InputMap<string> Tags
         {
@@ -164,7 +204,7 @@ namespace Caliban0.PulumiCherryServers.Provider
         }
 
         /// <summary>
-        /// Server that this address is targeted to.
+        /// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         /// </summary>
         [Input("targetedTo")]
         public Input<int>? TargetedTo { get; set; }
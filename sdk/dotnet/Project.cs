@@ -8,20 +8,32 @@ using System.Threading.Tasks;
 using Pulumi.Serialization;
 using Pulumi;
 
-namespace Caliban0.PulumiCherryServers.Provider
+namespace Caliban0.PulumiCherryServers
 {
     /// <summary>
     /// A Cherry Servers project.
     /// </summary>
-    [PulumiCherryServersResourceType("pulumi-cherry-servers:provider:Project")]
+    [PulumiCherryServersResourceType("pulumi-cherry-servers:index:Project")]
     public partial class Project : global::Pulumi.CustomResource
     {
         /// <summary>
-        /// Whether BGP should be enabled for the project.
+        /// Whether BGP should be enabled for the project. Removing it disables BGP.
         /// </summary>
         [Output("bgp")]
         public Output<bool?> Bgp { get; private set; } = null!;
 
+        /// <summary>
+        /// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        /// </summary>
+        [Output("deletionProtection")]
+        public Output<bool?> DeletionProtection { get; private set; } = null!;
+
+        /// <summary>
+        /// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        /// </summary>
+        [Output("forceDestroy")]
+        public Output<bool?> ForceDestroy { get; private set; } = null!;
+
         /// <summary>
         /// LocalASN assigned to the project.
         /// </summary>
@@ -29,7 +41,7 @@ namespace Caliban0.PulumiCherryServers.Provider
         public Output<int?> LocalASN { get; private set; } = null!;
 
         /// <summary>
-        /// Project name.
+        /// Project name. If removed, the current name is kept.
         /// </summary>
         [Output("name")]
         public Output<string?> Name { get; private set; } = null!;
@@ -49,12 +61,12 @@ namespace Caliban0.PulumiCherryServers.Provider
         /// <param name="args">The arguments used to populate this resource's properties</param>
         /// <param name="options">A bag of options that control this resource's behavior</param>
         public Project(string name, ProjectArgs args, CustomResourceOptions? options = null)
-            : base("pulumi-cherry-servers:provider:Project", name, args ?? new ProjectArgs(), MakeResourceOptions(options, ""))
+            : base("pulumi-cherry-servers:index:Project", name, args ?? new ProjectArgs(), MakeResourceOptions(options, ""))
         {
         }
 
         private Project(string name, Input<string> id, CustomResourceOptions? options = null)
-            : base("pulumi-cherry-servers:provider:Project", name, null, MakeResourceOptions(options, id))
+            : base("pulumi-cherry-servers:index:Project", name, null, MakeResourceOptions(options, id))
         {
         }
 
@@ -63,6 +75,10 @@ namespace Caliban0.PulumiCherryServers.Provider
             var defaultOptions = new CustomResourceOptions
             {
                 Version = Utilities.Version,
+                Aliases =
+                {
+                    new global::Pulumi.Alias { Type = "pulumi-cherry-servers:provider:Project" },
+                },
             };
             var merged = CustomResourceOptions.Merge(defaultOptions, options);
             // Override the ID if one was specified for consistency with other language SDKs.
@@ -86,13 +102,25 @@ namespace Caliban0.PulumiCherryServers.Provider
     public sealed class ProjectArgs : global::Pulumi.ResourceArgs
     {
         /// <summary>
-        /// Whether BGP should be enabled for the project.
+        /// Whether BGP should be enabled for the project. Removing it disables BGP.
         /// </summary>
         [Input("bgp")]
         public Input<bool>? Bgp { get; set; }
 
         /// <summary>
-        /// Project name.
+        /// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        /// </summary>
+        [Input("deletionProtection")]
+        public Input<bool>? DeletionProtection { get; set; }
+
+        /// <summary>
+        /// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        /// </summary>
+        [Input("forceDestroy")]
+        public Input<bool>? ForceDestroy { get; set; }
+
+        /// <summary>
+        /// Project name. If removed, the current name is kept.
         /// </summary>
         [Input("name")]
         public Input<string>? Name { get; set; }
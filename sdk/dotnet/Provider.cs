@@ -13,6 +13,12 @@ namespace Caliban0.PulumiCherryServers
     [PulumiCherryServersResourceType("pulumi:providers:pulumi-cherry-servers")]
     public partial class Provider : global::Pulumi.ProviderResource
     {
+        /// <summary>
+        /// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        /// </summary>
+        [Output("auditLog")]
+        public Output<string?> AuditLog { get; private set; } = null!;
+
         /// <summary>
         /// Cherry Servers API token.
         /// </summary>
@@ -51,6 +57,24 @@ namespace Caliban0.PulumiCherryServers
 
     public sealed class ProviderArgs : global::Pulumi.ResourceArgs
     {
+        /// <summary>
+        /// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        /// </summary>
+        [Input("auditLog")]
+        public Input<string>? AuditLog { get; set; }
+
+        /// <summary>
+        /// Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        /// </summary>
+        [Input("burst", json: true)]
+        public Input<int>? Burst { get; set; }
+
+        /// <summary>
+        /// Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+        /// </summary>
+        [Input("requestsPerSecond", json: true)]
+        public Input<double>? RequestsPerSecond { get; set; }
+
         [Input("token", required: true)]
         private Input<string>? _token;
 
@@ -69,6 +93,8 @@ namespace Caliban0.PulumiCherryServers
 
         public ProviderArgs()
         {
+            Burst = 10;
+            RequestsPerSecond = 5;
         }
         public static new ProviderArgs Empty => new ProviderArgs();
     }
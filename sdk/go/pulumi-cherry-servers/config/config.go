@@ -11,6 +11,33 @@ import (
 
 var _ = internal.GetEnvOrDefault
 
+// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+func GetAuditLog(ctx *pulumi.Context) string {
+	return config.Get(ctx, "pulumi-cherry-servers:auditLog")
+}
+
+// Maximum number of API requests that can be made at once, before rate limiting kicks in.
+func GetBurst(ctx *pulumi.Context) int {
+	v, err := config.TryInt(ctx, "pulumi-cherry-servers:burst")
+	if err == nil {
+		return v
+	}
+	var value int
+	value = 10
+	return value
+}
+
+// Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+func GetRequestsPerSecond(ctx *pulumi.Context) float64 {
+	v, err := config.TryFloat64(ctx, "pulumi-cherry-servers:requestsPerSecond")
+	if err == nil {
+		return v
+	}
+	var value float64
+	value = 5.0
+	return value
+}
+
 // Cherry Servers API token.
 func GetToken(ctx *pulumi.Context) string {
 	return config.Get(ctx, "pulumi-cherry-servers:token")
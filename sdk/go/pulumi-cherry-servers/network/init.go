@@ -1,7 +1,7 @@
 // Code generated by pulumi-language-go DO NOT EDIT.
 // *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
 
-package provider
+package network
 
 import (
 	"fmt"
@@ -21,10 +21,8 @@ func (m *module) Version() semver.Version {
 
 func (m *module) Construct(ctx *pulumi.Context, name, typ, urn string) (r pulumi.Resource, err error) {
 	switch typ {
-	case "pulumi-cherry-servers:provider:IP":
+	case "pulumi-cherry-servers:network:IP":
 		r = &IP{}
-	case "pulumi-cherry-servers:provider:Project":
-		r = &Project{}
 	default:
 		return nil, fmt.Errorf("unknown resource type: %s", typ)
 	}
@@ -40,7 +38,7 @@ func init() {
 	}
 	pulumi.RegisterResourceModule(
 		"pulumi-cherry-servers",
-		"provider",
+		"network",
 		&module{version},
 	)
 }
@@ -1,7 +1,7 @@
 // Code generated by pulumi-language-go DO NOT EDIT.
 // *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
 
-package provider
+package network
 
 import (
 	"context"
@@ -16,24 +16,31 @@ import (
 type IP struct {
 	pulumi.CustomResourceState
 
-	// IP address A record.
+	// IP address A record. Removing it clears the record.
 	ARecord pulumi.StringPtrOutput `pulumi:"aRecord"`
 	// Actual address.
 	Address pulumi.StringOutput `pulumi:"address"`
 	// IP address family.
 	AddressFamily pulumi.IntOutput `pulumi:"addressFamily"`
 	// IP address CIDR.
-	Cidr    pulumi.StringOutput `pulumi:"cidr"`
-	Project pulumi.IntOutput    `pulumi:"project"`
-	// IP address PTR record.
-	PtrRecord pulumi.StringPtrOutput `pulumi:"ptrRecord"`
+	Cidr pulumi.StringOutput `pulumi:"cidr"`
+	// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+	DeletionProtection pulumi.BoolPtrOutput `pulumi:"deletionProtection"`
 	// IP address project ID.
+	Project pulumi.IntOutput `pulumi:"project"`
+	// IP address PTR record. Removing it clears the record.
+	PtrRecord pulumi.StringPtrOutput `pulumi:"ptrRecord"`
+	// IP address region slug.
 	Region pulumi.StringOutput `pulumi:"region"`
-	// IP address that this address is routed to.
+	// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+	RetainOnDelete pulumi.BoolPtrOutput `pulumi:"retainOnDelete"`
+	// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
 	RoutedTo pulumi.StringPtrOutput `pulumi:"routedTo"`
-	// IP address tags.
+	// Version of the state layout, used to upgrade state written by older provider versions.
+	StateVersion pulumi.IntPtrOutput `pulumi:"stateVersion"`
+	// IP address tags. Removing them clears all tags.
 	Tags pulumi.StringMapOutput `pulumi:"tags"`
-	// Server that this address is targeted to.
+	// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
 	TargetedTo pulumi.IntPtrOutput `pulumi:"targetedTo"`
 	// IP address type.
 	Type pulumi.StringOutput `pulumi:"type"`
@@ -52,9 +59,15 @@ func NewIP(ctx *pulumi.Context,
 	if args.Region == nil {
 		return nil, errors.New("invalid value for required argument 'Region'")
 	}
+	aliases := pulumi.Aliases([]pulumi.Alias{
+		{
+			Type: pulumi.String("pulumi-cherry-servers:provider:IP"),
+		},
+	})
+	opts = append(opts, aliases)
 	opts = internal.PkgResourceDefaultOpts(opts)
 	var resource IP
-	err := ctx.RegisterResource("pulumi-cherry-servers:provider:IP", name, args, &resource, opts...)
+	err := ctx.RegisterResource("pulumi-cherry-servers:network:IP", name, args, &resource, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +79,7 @@ func NewIP(ctx *pulumi.Context,
 func GetIP(ctx *pulumi.Context,
 	name string, id pulumi.IDInput, state *IPState, opts ...pulumi.ResourceOption) (*IP, error) {
 	var resource IP
-	err := ctx.ReadResource("pulumi-cherry-servers:provider:IP", name, id, state, &resource, opts...)
+	err := ctx.ReadResource("pulumi-cherry-servers:network:IP", name, id, state, &resource, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,35 +98,45 @@ func (IPState) ElementType() reflect.Type {
 }
 
 type ipArgs struct {
-	// IP address A record.
+	// IP address A record. Removing it clears the record.
 	ARecord *string `pulumi:"aRecord"`
-	Project int     `pulumi:"project"`
-	// IP address PTR record.
-	PtrRecord *string `pulumi:"ptrRecord"`
+	// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+	DeletionProtection *bool `pulumi:"deletionProtection"`
 	// IP address project ID.
+	Project int `pulumi:"project"`
+	// IP address PTR record. Removing it clears the record.
+	PtrRecord *string `pulumi:"ptrRecord"`
+	// IP address region slug.
 	Region string `pulumi:"region"`
-	// IP address that this address is routed to.
+	// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+	RetainOnDelete *bool `pulumi:"retainOnDelete"`
+	// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
 	RoutedTo *string `pulumi:"routedTo"`
-	// IP address tags.
+	// IP address tags. Removing them clears all tags.
 	Tags map[string]string `pulumi:"tags"`
-	// Server that this address is targeted to.
+	// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
 	TargetedTo *int `pulumi:"targetedTo"`
 }
 
 // The set of arguments for constructing a IP resource.
 type IPArgs struct {
-	// IP address A record.
+	// IP address A record. Removing it clears the record.
 	ARecord pulumi.StringPtrInput
+	// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+	DeletionProtection pulumi.BoolPtrInput
+	// IP address project ID.
 	Project pulumi.IntInput
-	// IP address PTR record.
+	// IP address PTR record. Removing it clears the record.
 	PtrRecord pulumi.StringPtrInput
-	// IP address project ID.
+	// IP address region slug.
 	Region pulumi.StringInput
-	// IP address that this address is routed to.
+	// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+	RetainOnDelete pulumi.BoolPtrInput
+	// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
 	RoutedTo pulumi.StringPtrInput
-	// IP address tags.
+	// IP address tags. Removing them clears all tags.
 	Tags pulumi.StringMapInput
-	// Server that this address is targeted to.
+	// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
 	TargetedTo pulumi.IntPtrInput
 }
 
@@ -204,7 +227,7 @@ func (o IPOutput) ToIPOutputWithContext(ctx context.Context) IPOutput {
 	return o
 }
 
-// IP address A record.
+// IP address A record. Removing it clears the record.
 func (o IPOutput) ARecord() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringPtrOutput { return v.ARecord }).(pulumi.StringPtrOutput)
 }
@@ -224,31 +247,47 @@ func (o IPOutput) Cidr() pulumi.StringOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringOutput { return v.Cidr }).(pulumi.StringOutput)
 }
 
+// Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+func (o IPOutput) DeletionProtection() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *IP) pulumi.BoolPtrOutput { return v.DeletionProtection }).(pulumi.BoolPtrOutput)
+}
+
+// IP address project ID.
 func (o IPOutput) Project() pulumi.IntOutput {
 	return o.ApplyT(func(v *IP) pulumi.IntOutput { return v.Project }).(pulumi.IntOutput)
 }
 
-// IP address PTR record.
+// IP address PTR record. Removing it clears the record.
 func (o IPOutput) PtrRecord() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringPtrOutput { return v.PtrRecord }).(pulumi.StringPtrOutput)
 }
 
-// IP address project ID.
+// IP address region slug.
 func (o IPOutput) Region() pulumi.StringOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringOutput { return v.Region }).(pulumi.StringOutput)
 }
 
-// IP address that this address is routed to.
+// Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+func (o IPOutput) RetainOnDelete() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *IP) pulumi.BoolPtrOutput { return v.RetainOnDelete }).(pulumi.BoolPtrOutput)
+}
+
+// IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
 func (o IPOutput) RoutedTo() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringPtrOutput { return v.RoutedTo }).(pulumi.StringPtrOutput)
 }
 
-// IP address tags.
+// Version of the state layout, used to upgrade state written by older provider versions.
+func (o IPOutput) StateVersion() pulumi.IntPtrOutput {
+	return o.ApplyT(func(v *IP) pulumi.IntPtrOutput { return v.StateVersion }).(pulumi.IntPtrOutput)
+}
+
+// IP address tags. Removing them clears all tags.
 func (o IPOutput) Tags() pulumi.StringMapOutput {
 	return o.ApplyT(func(v *IP) pulumi.StringMapOutput { return v.Tags }).(pulumi.StringMapOutput)
 }
 
-// Server that this address is targeted to.
+// Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
 func (o IPOutput) TargetedTo() pulumi.IntPtrOutput {
 	return o.ApplyT(func(v *IP) pulumi.IntPtrOutput { return v.TargetedTo }).(pulumi.IntPtrOutput)
 }
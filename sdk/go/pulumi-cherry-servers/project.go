@@ -1,7 +1,7 @@
 // Code generated by pulumi-language-go DO NOT EDIT.
 // *** WARNING: Do not edit by hand unless you're certain you know what you are doing! ***
 
-package provider
+package pulumicherryservers
 
 import (
 	"context"
@@ -16,11 +16,15 @@ import (
 type Project struct {
 	pulumi.CustomResourceState
 
-	// Whether BGP should be enabled for the project.
+	// Whether BGP should be enabled for the project. Removing it disables BGP.
 	Bgp pulumi.BoolPtrOutput `pulumi:"bgp"`
+	// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+	DeletionProtection pulumi.BoolPtrOutput `pulumi:"deletionProtection"`
+	// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+	ForceDestroy pulumi.BoolPtrOutput `pulumi:"forceDestroy"`
 	// LocalASN assigned to the project.
 	LocalASN pulumi.IntPtrOutput `pulumi:"localASN"`
-	// Project name.
+	// Project name. If removed, the current name is kept.
 	Name pulumi.StringPtrOutput `pulumi:"name"`
 	// ID of the team the project belongs to.
 	Team pulumi.IntOutput `pulumi:"team"`
@@ -36,9 +40,15 @@ func NewProject(ctx *pulumi.Context,
 	if args.Team == nil {
 		return nil, errors.New("invalid value for required argument 'Team'")
 	}
+	aliases := pulumi.Aliases([]pulumi.Alias{
+		{
+			Type: pulumi.String("pulumi-cherry-servers:provider:Project"),
+		},
+	})
+	opts = append(opts, aliases)
 	opts = internal.PkgResourceDefaultOpts(opts)
 	var resource Project
-	err := ctx.RegisterResource("pulumi-cherry-servers:provider:Project", name, args, &resource, opts...)
+	err := ctx.RegisterResource("pulumi-cherry-servers:index:Project", name, args, &resource, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +60,7 @@ func NewProject(ctx *pulumi.Context,
 func GetProject(ctx *pulumi.Context,
 	name string, id pulumi.IDInput, state *ProjectState, opts ...pulumi.ResourceOption) (*Project, error) {
 	var resource Project
-	err := ctx.ReadResource("pulumi-cherry-servers:provider:Project", name, id, state, &resource, opts...)
+	err := ctx.ReadResource("pulumi-cherry-servers:index:Project", name, id, state, &resource, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,9 +79,13 @@ func (ProjectState) ElementType() reflect.Type {
 }
 
 type projectArgs struct {
-	// Whether BGP should be enabled for the project.
+	// Whether BGP should be enabled for the project. Removing it disables BGP.
 	Bgp *bool `pulumi:"bgp"`
-	// Project name.
+	// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+	DeletionProtection *bool `pulumi:"deletionProtection"`
+	// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+	ForceDestroy *bool `pulumi:"forceDestroy"`
+	// Project name. If removed, the current name is kept.
 	Name *string `pulumi:"name"`
 	// ID of the team the project belongs to.
 	Team int `pulumi:"team"`
@@ -79,9 +93,13 @@ type projectArgs struct {
 
 // The set of arguments for constructing a Project resource.
 type ProjectArgs struct {
-	// Whether BGP should be enabled for the project.
+	// Whether BGP should be enabled for the project. Removing it disables BGP.
 	Bgp pulumi.BoolPtrInput
-	// Project name.
+	// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+	DeletionProtection pulumi.BoolPtrInput
+	// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+	ForceDestroy pulumi.BoolPtrInput
+	// Project name. If removed, the current name is kept.
 	Name pulumi.StringPtrInput
 	// ID of the team the project belongs to.
 	Team pulumi.IntInput
@@ -174,17 +192,27 @@ func (o ProjectOutput) ToProjectOutputWithContext(ctx context.Context) ProjectOu
 	return o
 }
 
-// Whether BGP should be enabled for the project.
+// Whether BGP should be enabled for the project. Removing it disables BGP.
 func (o ProjectOutput) Bgp() pulumi.BoolPtrOutput {
 	return o.ApplyT(func(v *Project) pulumi.BoolPtrOutput { return v.Bgp }).(pulumi.BoolPtrOutput)
 }
 
+// Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+func (o ProjectOutput) DeletionProtection() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *Project) pulumi.BoolPtrOutput { return v.DeletionProtection }).(pulumi.BoolPtrOutput)
+}
+
+// Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+func (o ProjectOutput) ForceDestroy() pulumi.BoolPtrOutput {
+	return o.ApplyT(func(v *Project) pulumi.BoolPtrOutput { return v.ForceDestroy }).(pulumi.BoolPtrOutput)
+}
+
 // LocalASN assigned to the project.
 func (o ProjectOutput) LocalASN() pulumi.IntPtrOutput {
 	return o.ApplyT(func(v *Project) pulumi.IntPtrOutput { return v.LocalASN }).(pulumi.IntPtrOutput)
 }
 
-// Project name.
+// Project name. If removed, the current name is kept.
 func (o ProjectOutput) Name() pulumi.StringPtrOutput {
 	return o.ApplyT(func(v *Project) pulumi.StringPtrOutput { return v.Name }).(pulumi.StringPtrOutput)
 }
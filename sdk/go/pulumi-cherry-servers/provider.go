@@ -15,6 +15,8 @@ import (
 type Provider struct {
 	pulumi.ProviderResourceState
 
+	// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+	AuditLog pulumi.StringPtrOutput `pulumi:"auditLog"`
 	// Cherry Servers API token.
 	Token pulumi.StringOutput `pulumi:"token"`
 }
@@ -29,6 +31,12 @@ func NewProvider(ctx *pulumi.Context,
 	if args.Token == nil {
 		return nil, errors.New("invalid value for required argument 'Token'")
 	}
+	if args.Burst == nil {
+		args.Burst = pulumi.IntPtr(10)
+	}
+	if args.RequestsPerSecond == nil {
+		args.RequestsPerSecond = pulumi.Float64Ptr(5.0)
+	}
 	if args.Token != nil {
 		args.Token = pulumi.ToSecret(args.Token).(pulumi.StringInput)
 	}
@@ -46,12 +54,24 @@ func NewProvider(ctx *pulumi.Context,
 }
 
 type providerArgs struct {
+	// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+	AuditLog *string `pulumi:"auditLog"`
+	// Maximum number of API requests that can be made at once, before rate limiting kicks in.
+	Burst *int `pulumi:"burst"`
+	// Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+	RequestsPerSecond *float64 `pulumi:"requestsPerSecond"`
 	// Cherry Servers API token.
 	Token string `pulumi:"token"`
 }
 
 // The set of arguments for constructing a Provider resource.
 type ProviderArgs struct {
+	// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+	AuditLog pulumi.StringPtrInput
+	// Maximum number of API requests that can be made at once, before rate limiting kicks in.
+	Burst pulumi.IntPtrInput
+	// Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+	RequestsPerSecond pulumi.Float64PtrInput
 	// Cherry Servers API token.
 	Token pulumi.StringInput
 }
@@ -93,6 +113,11 @@ func (o ProviderOutput) ToProviderOutputWithContext(ctx context.Context) Provide
 	return o
 }
 
+// Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+func (o ProviderOutput) AuditLog() pulumi.StringPtrOutput {
+	return o.ApplyT(func(v *Provider) pulumi.StringPtrOutput { return v.AuditLog }).(pulumi.StringPtrOutput)
+}
+
 // Cherry Servers API token.
 func (o ProviderOutput) Token() pulumi.StringOutput {
 	return o.ApplyT(func(v *Provider) pulumi.StringOutput { return v.Token }).(pulumi.StringOutput)
@@ -4,11 +4,35 @@
 package com.caliban0.pulumicherryservers;
 
 import com.pulumi.core.internal.Codegen;
+import java.lang.Double;
+import java.lang.Integer;
 import java.lang.String;
+import java.util.Optional;
 
 public final class Config {
 
     private static final com.pulumi.Config config = com.pulumi.Config.of("pulumi-cherry-servers");
+/**
+ * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+ * 
+ */
+    public Optional<String> auditLog() {
+        return Codegen.stringProp("auditLog").config(config).get();
+    }
+/**
+ * Maximum number of API requests that can be made at once, before rate limiting kicks in.
+ * 
+ */
+    public Optional<Integer> burst() {
+        return Codegen.integerProp("burst").config(config).def(10).get();
+    }
+/**
+ * Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+ * 
+ */
+    public Optional<Double> requestsPerSecond() {
+        return Codegen.doubleProp("requestsPerSecond").config(config).def(5e+00).get();
+    }
 /**
  * Cherry Servers API token.
  * 
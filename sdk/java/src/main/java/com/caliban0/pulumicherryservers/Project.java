@@ -1,10 +1,11 @@
 // *** WARNING: this file was generated by pulumi-language-java. ***
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
-package com.caliban0.pulumicherryservers.provider;
+package com.caliban0.pulumicherryservers;
 
+import com.caliban0.pulumicherryservers.ProjectArgs;
 import com.caliban0.pulumicherryservers.Utilities;
-import com.caliban0.pulumicherryservers.provider.ProjectArgs;
+import com.pulumi.core.Alias;
 import com.pulumi.core.Output;
 import com.pulumi.core.annotations.Export;
 import com.pulumi.core.annotations.ResourceType;
@@ -12,6 +13,7 @@ import com.pulumi.core.internal.Codegen;
 import java.lang.Boolean;
 import java.lang.Integer;
 import java.lang.String;
+import java.util.List;
 import java.util.Optional;
 import javax.annotation.Nullable;
 
@@ -19,22 +21,50 @@ import javax.annotation.Nullable;
  * A Cherry Servers project.
  * 
  */
-@ResourceType(type="pulumi-cherry-servers:provider:Project")
+@ResourceType(type="pulumi-cherry-servers:index:Project")
 public class Project extends com.pulumi.resources.CustomResource {
     /**
-     * Whether BGP should be enabled for the project.
+     * Whether BGP should be enabled for the project. Removing it disables BGP.
      * 
      */
     @Export(name="bgp", refs={Boolean.class}, tree="[0]")
     private Output</* @Nullable */ Boolean> bgp;
 
     /**
-     * @return Whether BGP should be enabled for the project.
+     * @return Whether BGP should be enabled for the project. Removing it disables BGP.
      * 
      */
     public Output<Optional<Boolean>> bgp() {
         return Codegen.optional(this.bgp);
     }
+    /**
+     * Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     * 
+     */
+    @Export(name="deletionProtection", refs={Boolean.class}, tree="[0]")
+    private Output</* @Nullable */ Boolean> deletionProtection;
+
+    /**
+     * @return Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     * 
+     */
+    public Output<Optional<Boolean>> deletionProtection() {
+        return Codegen.optional(this.deletionProtection);
+    }
+    /**
+     * Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+     * 
+     */
+    @Export(name="forceDestroy", refs={Boolean.class}, tree="[0]")
+    private Output</* @Nullable */ Boolean> forceDestroy;
+
+    /**
+     * @return Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+     * 
+     */
+    public Output<Optional<Boolean>> forceDestroy() {
+        return Codegen.optional(this.forceDestroy);
+    }
     /**
      * LocalASN assigned to the project.
      * 
@@ -50,14 +80,14 @@ public class Project extends com.pulumi.resources.CustomResource {
         return Codegen.optional(this.localASN);
     }
     /**
-     * Project name.
+     * Project name. If removed, the current name is kept.
      * 
      */
     @Export(name="name", refs={String.class}, tree="[0]")
     private Output</* @Nullable */ String> name;
 
     /**
-     * @return Project name.
+     * @return Project name. If removed, the current name is kept.
      * 
      */
     public Output<Optional<String>> name() {
@@ -100,11 +130,11 @@ public class Project extends com.pulumi.resources.CustomResource {
      * @param options A bag of options that control this resource's behavior.
      */
     public Project(java.lang.String name, ProjectArgs args, @Nullable com.pulumi.resources.CustomResourceOptions options) {
-        super("pulumi-cherry-servers:provider:Project", name, makeArgs(args, options), makeResourceOptions(options, Codegen.empty()), false);
+        super("pulumi-cherry-servers:index:Project", name, makeArgs(args, options), makeResourceOptions(options, Codegen.empty()), false);
     }
 
     private Project(java.lang.String name, Output<java.lang.String> id, @Nullable com.pulumi.resources.CustomResourceOptions options) {
-        super("pulumi-cherry-servers:provider:Project", name, null, makeResourceOptions(options, id), false);
+        super("pulumi-cherry-servers:index:Project", name, null, makeResourceOptions(options, id), false);
     }
 
     private static ProjectArgs makeArgs(ProjectArgs args, @Nullable com.pulumi.resources.CustomResourceOptions options) {
@@ -117,6 +147,9 @@ public class Project extends com.pulumi.resources.CustomResource {
     private static com.pulumi.resources.CustomResourceOptions makeResourceOptions(@Nullable com.pulumi.resources.CustomResourceOptions options, @Nullable Output<java.lang.String> id) {
         var defaultOptions = com.pulumi.resources.CustomResourceOptions.builder()
             .version(Utilities.getVersion())
+            .aliases(List.of(
+                Output.of(Alias.builder().type("pulumi-cherry-servers:provider:Project").build())
+            ))
             .build();
         return com.pulumi.resources.CustomResourceOptions.merge(defaultOptions, options, id);
     }
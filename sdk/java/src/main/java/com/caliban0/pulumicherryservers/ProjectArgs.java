@@ -0,0 +1,237 @@
+// *** WARNING: this file was generated by pulumi-language-java. ***
+// *** Do not edit by hand unless you're certain you know what you are doing! ***
+
+package com.caliban0.pulumicherryservers;
+
+import com.pulumi.core.Output;
+import com.pulumi.core.annotations.Import;
+import com.pulumi.exceptions.MissingRequiredPropertyException;
+import java.lang.Boolean;
+import java.lang.Integer;
+import java.lang.String;
+import java.util.Objects;
+import java.util.Optional;
+import javax.annotation.Nullable;
+
+
+public final class ProjectArgs extends com.pulumi.resources.ResourceArgs {
+
+    public static final ProjectArgs Empty = new ProjectArgs();
+
+    /**
+     * Whether BGP should be enabled for the project. Removing it disables BGP.
+     * 
+     */
+    @Import(name="bgp")
+    private @Nullable Output<Boolean> bgp;
+
+    /**
+     * @return Whether BGP should be enabled for the project. Removing it disables BGP.
+     * 
+     */
+    public Optional<Output<Boolean>> bgp() {
+        return Optional.ofNullable(this.bgp);
+    }
+
+    /**
+     * Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     * 
+     */
+    @Import(name="deletionProtection")
+    private @Nullable Output<Boolean> deletionProtection;
+
+    /**
+     * @return Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     * 
+     */
+    public Optional<Output<Boolean>> deletionProtection() {
+        return Optional.ofNullable(this.deletionProtection);
+    }
+
+    /**
+     * Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+     * 
+     */
+    @Import(name="forceDestroy")
+    private @Nullable Output<Boolean> forceDestroy;
+
+    /**
+     * @return Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+     * 
+     */
+    public Optional<Output<Boolean>> forceDestroy() {
+        return Optional.ofNullable(this.forceDestroy);
+    }
+
+    /**
+     * Project name. If removed, the current name is kept.
+     * 
+     */
+    @Import(name="name")
+    private @Nullable Output<String> name;
+
+    /**
+     * @return Project name. If removed, the current name is kept.
+     * 
+     */
+    public Optional<Output<String>> name() {
+        return Optional.ofNullable(this.name);
+    }
+
+    /**
+     * ID of the team the project belongs to.
+     * 
+     */
+    @Import(name="team", required=true)
+    private Output<Integer> team;
+
+    /**
+     * @return ID of the team the project belongs to.
+     * 
+     */
+    public Output<Integer> team() {
+        return this.team;
+    }
+
+    private ProjectArgs() {}
+
+    private ProjectArgs(ProjectArgs $) {
+        this.bgp = $.bgp;
+        this.deletionProtection = $.deletionProtection;
+        this.forceDestroy = $.forceDestroy;
+        this.name = $.name;
+        this.team = $.team;
+    }
+
+    public static Builder builder() {
+        return new Builder();
+    }
+    public static Builder builder(ProjectArgs defaults) {
+        return new Builder(defaults);
+    }
+
+    public static final class Builder {
+        private ProjectArgs $;
+
+        public Builder() {
+            $ = new ProjectArgs();
+        }
+
+        public Builder(ProjectArgs defaults) {
+            $ = new ProjectArgs(Objects.requireNonNull(defaults));
+        }
+
+        /**
+         * @param bgp Whether BGP should be enabled for the project. Removing it disables BGP.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder bgp(@Nullable Output<Boolean> bgp) {
+            $.bgp = bgp;
+            return this;
+        }
+
+        /**
+         * @param bgp Whether BGP should be enabled for the project. Removing it disables BGP.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder bgp(Boolean bgp) {
+            return bgp(Output.of(bgp));
+        }
+
+        /**
+         * @param deletionProtection Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder deletionProtection(@Nullable Output<Boolean> deletionProtection) {
+            $.deletionProtection = deletionProtection;
+            return this;
+        }
+
+        /**
+         * @param deletionProtection Whether the project can&#39;t be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder deletionProtection(Boolean deletionProtection) {
+            return deletionProtection(Output.of(deletionProtection));
+        }
+
+        /**
+         * @param forceDestroy Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder forceDestroy(@Nullable Output<Boolean> forceDestroy) {
+            $.forceDestroy = forceDestroy;
+            return this;
+        }
+
+        /**
+         * @param forceDestroy Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn&#39;t empty fails. It has to be enabled in an update before the delete.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder forceDestroy(Boolean forceDestroy) {
+            return forceDestroy(Output.of(forceDestroy));
+        }
+
+        /**
+         * @param name Project name. If removed, the current name is kept.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder name(@Nullable Output<String> name) {
+            $.name = name;
+            return this;
+        }
+
+        /**
+         * @param name Project name. If removed, the current name is kept.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder name(String name) {
+            return name(Output.of(name));
+        }
+
+        /**
+         * @param team ID of the team the project belongs to.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder team(Output<Integer> team) {
+            $.team = team;
+            return this;
+        }
+
+        /**
+         * @param team ID of the team the project belongs to.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder team(Integer team) {
+            return team(Output.of(team));
+        }
+
+        public ProjectArgs build() {
+            if ($.team == null) {
+                throw new MissingRequiredPropertyException("ProjectArgs", "team");
+            }
+            return $;
+        }
+    }
+
+}
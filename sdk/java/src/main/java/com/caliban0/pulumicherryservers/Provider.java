@@ -11,10 +11,25 @@ import com.pulumi.core.annotations.ResourceType;
 import com.pulumi.core.internal.Codegen;
 import java.lang.String;
 import java.util.List;
+import java.util.Optional;
 import javax.annotation.Nullable;
 
 @ResourceType(type="pulumi:providers:pulumi-cherry-servers")
 public class Provider extends com.pulumi.resources.ProviderResource {
+    /**
+     * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     * 
+     */
+    @Export(name="auditLog", refs={String.class}, tree="[0]")
+    private Output</* @Nullable */ String> auditLog;
+
+    /**
+     * @return Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     * 
+     */
+    public Output<Optional<String>> auditLog() {
+        return Codegen.optional(this.auditLog);
+    }
     /**
      * Cherry Servers API token.
      * 
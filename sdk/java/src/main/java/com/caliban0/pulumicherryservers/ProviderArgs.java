@@ -5,15 +5,65 @@ package com.caliban0.pulumicherryservers;
 
 import com.pulumi.core.Output;
 import com.pulumi.core.annotations.Import;
+import com.pulumi.core.internal.Codegen;
 import com.pulumi.exceptions.MissingRequiredPropertyException;
+import java.lang.Double;
+import java.lang.Integer;
 import java.lang.String;
 import java.util.Objects;
+import java.util.Optional;
+import javax.annotation.Nullable;
 
 
 public final class ProviderArgs extends com.pulumi.resources.ResourceArgs {
 
     public static final ProviderArgs Empty = new ProviderArgs();
 
+    /**
+     * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     * 
+     */
+    @Import(name="auditLog")
+    private @Nullable Output<String> auditLog;
+
+    /**
+     * @return Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     * 
+     */
+    public Optional<Output<String>> auditLog() {
+        return Optional.ofNullable(this.auditLog);
+    }
+
+    /**
+     * Maximum number of API requests that can be made at once, before rate limiting kicks in.
+     * 
+     */
+    @Import(name="burst", json=true)
+    private @Nullable Output<Integer> burst;
+
+    /**
+     * @return Maximum number of API requests that can be made at once, before rate limiting kicks in.
+     * 
+     */
+    public Optional<Output<Integer>> burst() {
+        return Optional.ofNullable(this.burst);
+    }
+
+    /**
+     * Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+     * 
+     */
+    @Import(name="requestsPerSecond", json=true)
+    private @Nullable Output<Double> requestsPerSecond;
+
+    /**
+     * @return Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+     * 
+     */
+    public Optional<Output<Double>> requestsPerSecond() {
+        return Optional.ofNullable(this.requestsPerSecond);
+    }
+
     /**
      * Cherry Servers API token.
      * 
@@ -32,6 +82,9 @@ public final class ProviderArgs extends com.pulumi.resources.ResourceArgs {
     private ProviderArgs() {}
 
     private ProviderArgs(ProviderArgs $) {
+        this.auditLog = $.auditLog;
+        this.burst = $.burst;
+        this.requestsPerSecond = $.requestsPerSecond;
         this.token = $.token;
     }
 
@@ -53,6 +106,69 @@ public final class ProviderArgs extends com.pulumi.resources.ResourceArgs {
             $ = new ProviderArgs(Objects.requireNonNull(defaults));
         }
 
+        /**
+         * @param auditLog Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder auditLog(@Nullable Output<String> auditLog) {
+            $.auditLog = auditLog;
+            return this;
+        }
+
+        /**
+         * @param auditLog Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder auditLog(String auditLog) {
+            return auditLog(Output.of(auditLog));
+        }
+
+        /**
+         * @param burst Maximum number of API requests that can be made at once, before rate limiting kicks in.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder burst(@Nullable Output<Integer> burst) {
+            $.burst = burst;
+            return this;
+        }
+
+        /**
+         * @param burst Maximum number of API requests that can be made at once, before rate limiting kicks in.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder burst(Integer burst) {
+            return burst(Output.of(burst));
+        }
+
+        /**
+         * @param requestsPerSecond Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder requestsPerSecond(@Nullable Output<Double> requestsPerSecond) {
+            $.requestsPerSecond = requestsPerSecond;
+            return this;
+        }
+
+        /**
+         * @param requestsPerSecond Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder requestsPerSecond(Double requestsPerSecond) {
+            return requestsPerSecond(Output.of(requestsPerSecond));
+        }
+
         /**
          * @param token Cherry Servers API token.
          * 
@@ -75,6 +191,8 @@ public final class ProviderArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         public ProviderArgs build() {
+            $.burst = Codegen.integerProp("burst").output().arg($.burst).def(10).getNullable();
+            $.requestsPerSecond = Codegen.doubleProp("requestsPerSecond").output().arg($.requestsPerSecond).def(5e+00).getNullable();
             if ($.token == null) {
                 throw new MissingRequiredPropertyException("ProviderArgs", "token");
             }
@@ -1,16 +1,19 @@
 // *** WARNING: this file was generated by pulumi-language-java. ***
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
-package com.caliban0.pulumicherryservers.provider;
+package com.caliban0.pulumicherryservers.network;
 
 import com.caliban0.pulumicherryservers.Utilities;
-import com.caliban0.pulumicherryservers.provider.IPArgs;
+import com.caliban0.pulumicherryservers.network.IPArgs;
+import com.pulumi.core.Alias;
 import com.pulumi.core.Output;
 import com.pulumi.core.annotations.Export;
 import com.pulumi.core.annotations.ResourceType;
 import com.pulumi.core.internal.Codegen;
+import java.lang.Boolean;
 import java.lang.Integer;
 import java.lang.String;
+import java.util.List;
 import java.util.Map;
 import java.util.Optional;
 import javax.annotation.Nullable;
@@ -19,17 +22,17 @@ import javax.annotation.Nullable;
  * Cherry Servers IP address.
  * 
  */
-@ResourceType(type="pulumi-cherry-servers:provider:IP")
+@ResourceType(type="pulumi-cherry-servers:network:IP")
 public class IP extends com.pulumi.resources.CustomResource {
     /**
-     * IP address A record.
+     * IP address A record. Removing it clears the record.
      * 
      */
     @Export(name="aRecord", refs={String.class}, tree="[0]")
     private Output</* @Nullable */ String> aRecord;
 
     /**
-     * @return IP address A record.
+     * @return IP address A record. Removing it clears the record.
      * 
      */
     public Output<Optional<String>> aRecord() {
@@ -77,77 +80,127 @@ public class IP extends com.pulumi.resources.CustomResource {
     public Output<String> cidr() {
         return this.cidr;
     }
+    /**
+     * Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+     * 
+     */
+    @Export(name="deletionProtection", refs={Boolean.class}, tree="[0]")
+    private Output</* @Nullable */ Boolean> deletionProtection;
+
+    /**
+     * @return Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+     * 
+     */
+    public Output<Optional<Boolean>> deletionProtection() {
+        return Codegen.optional(this.deletionProtection);
+    }
+    /**
+     * IP address project ID.
+     * 
+     */
     @Export(name="project", refs={Integer.class}, tree="[0]")
     private Output<Integer> project;
 
+    /**
+     * @return IP address project ID.
+     * 
+     */
     public Output<Integer> project() {
         return this.project;
     }
     /**
-     * IP address PTR record.
+     * IP address PTR record. Removing it clears the record.
      * 
      */
     @Export(name="ptrRecord", refs={String.class}, tree="[0]")
     private Output</* @Nullable */ String> ptrRecord;
 
     /**
-     * @return IP address PTR record.
+     * @return IP address PTR record. Removing it clears the record.
      * 
      */
     public Output<Optional<String>> ptrRecord() {
         return Codegen.optional(this.ptrRecord);
     }
     /**
-     * IP address project ID.
+     * IP address region slug.
      * 
      */
     @Export(name="region", refs={String.class}, tree="[0]")
     private Output<String> region;
 
     /**
-     * @return IP address project ID.
+     * @return IP address region slug.
      * 
      */
     public Output<String> region() {
         return this.region;
     }
     /**
-     * IP address that this address is routed to.
+     * Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     * 
+     */
+    @Export(name="retainOnDelete", refs={Boolean.class}, tree="[0]")
+    private Output</* @Nullable */ Boolean> retainOnDelete;
+
+    /**
+     * @return Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     * 
+     */
+    public Output<Optional<Boolean>> retainOnDelete() {
+        return Codegen.optional(this.retainOnDelete);
+    }
+    /**
+     * IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      * 
      */
     @Export(name="routedTo", refs={String.class}, tree="[0]")
     private Output</* @Nullable */ String> routedTo;
 
     /**
-     * @return IP address that this address is routed to.
+     * @return IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      * 
      */
     public Output<Optional<String>> routedTo() {
         return Codegen.optional(this.routedTo);
     }
     /**
-     * IP address tags.
+     * Version of the state layout, used to upgrade state written by older provider versions.
+     * 
+     */
+    @Export(name="stateVersion", refs={Integer.class}, tree="[0]")
+    private Output</* @Nullable */ Integer> stateVersion;
+
+    /**
+     * @return Version of the state layout, used to upgrade state written by older provider versions.
+     * 
+     */
+    public Output<Optional<Integer>> stateVersion() {
+        return Codegen.optional(this.stateVersion);
+    }
+    /**
+     * IP address tags. Removing them clears all tags.
      * 
      */
     @Export(name="tags", refs={Map.class,String.class}, tree="[0,1,1]")
     private Output</* @Nullable */ Map<String,String>> tags;
 
     /**
-     * @return IP address tags.
+     * @return IP address tags. Removing them clears all tags.
      * 
      */
     public Output<Optional<Map<String,String>>> tags() {
         return Codegen.optional(this.tags);
     }
     /**
-     * Server that this address is targeted to.
+     * Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      * 
      */
     @Export(name="targetedTo", refs={Integer.class}, tree="[0]")
     private Output</* @Nullable */ Integer> targetedTo;
 
     /**
-     * @return Server that this address is targeted to.
+     * @return Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      * 
      */
     public Output<Optional<Integer>> targetedTo() {
@@ -190,11 +243,11 @@ public class IP extends com.pulumi.resources.CustomResource {
      * @param options A bag of options that control this resource's behavior.
      */
     public IP(java.lang.String name, IPArgs args, @Nullable com.pulumi.resources.CustomResourceOptions options) {
-        super("pulumi-cherry-servers:provider:IP", name, makeArgs(args, options), makeResourceOptions(options, Codegen.empty()), false);
+        super("pulumi-cherry-servers:network:IP", name, makeArgs(args, options), makeResourceOptions(options, Codegen.empty()), false);
     }
 
     private IP(java.lang.String name, Output<java.lang.String> id, @Nullable com.pulumi.resources.CustomResourceOptions options) {
-        super("pulumi-cherry-servers:provider:IP", name, null, makeResourceOptions(options, id), false);
+        super("pulumi-cherry-servers:network:IP", name, null, makeResourceOptions(options, id), false);
     }
 
     private static IPArgs makeArgs(IPArgs args, @Nullable com.pulumi.resources.CustomResourceOptions options) {
@@ -207,6 +260,9 @@ public class IP extends com.pulumi.resources.CustomResource {
     private static com.pulumi.resources.CustomResourceOptions makeResourceOptions(@Nullable com.pulumi.resources.CustomResourceOptions options, @Nullable Output<java.lang.String> id) {
         var defaultOptions = com.pulumi.resources.CustomResourceOptions.builder()
             .version(Utilities.getVersion())
+            .aliases(List.of(
+                Output.of(Alias.builder().type("pulumi-cherry-servers:provider:IP").build())
+            ))
             .build();
         return com.pulumi.resources.CustomResourceOptions.merge(defaultOptions, options, id);
     }
@@ -1,11 +1,12 @@
 // *** WARNING: this file was generated by pulumi-language-java. ***
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
-package com.caliban0.pulumicherryservers.provider;
+package com.caliban0.pulumicherryservers.network;
 
 import com.pulumi.core.Output;
 import com.pulumi.core.annotations.Import;
 import com.pulumi.exceptions.MissingRequiredPropertyException;
+import java.lang.Boolean;
 import java.lang.Integer;
 import java.lang.String;
 import java.util.Map;
@@ -19,36 +20,59 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
     public static final IPArgs Empty = new IPArgs();
 
     /**
-     * IP address A record.
+     * IP address A record. Removing it clears the record.
      * 
      */
     @Import(name="aRecord")
     private @Nullable Output<String> aRecord;
 
     /**
-     * @return IP address A record.
+     * @return IP address A record. Removing it clears the record.
      * 
      */
     public Optional<Output<String>> aRecord() {
         return Optional.ofNullable(this.aRecord);
     }
 
+    /**
+     * Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+     * 
+     */
+    @Import(name="deletionProtection")
+    private @Nullable Output<Boolean> deletionProtection;
+
+    /**
+     * @return Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+     * 
+     */
+    public Optional<Output<Boolean>> deletionProtection() {
+        return Optional.ofNullable(this.deletionProtection);
+    }
+
+    /**
+     * IP address project ID.
+     * 
+     */
     @Import(name="project", required=true)
     private Output<Integer> project;
 
+    /**
+     * @return IP address project ID.
+     * 
+     */
     public Output<Integer> project() {
         return this.project;
     }
 
     /**
-     * IP address PTR record.
+     * IP address PTR record. Removing it clears the record.
      * 
      */
     @Import(name="ptrRecord")
     private @Nullable Output<String> ptrRecord;
 
     /**
-     * @return IP address PTR record.
+     * @return IP address PTR record. Removing it clears the record.
      * 
      */
     public Optional<Output<String>> ptrRecord() {
@@ -56,14 +80,14 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
     }
 
     /**
-     * IP address project ID.
+     * IP address region slug.
      * 
      */
     @Import(name="region", required=true)
     private Output<String> region;
 
     /**
-     * @return IP address project ID.
+     * @return IP address region slug.
      * 
      */
     public Output<String> region() {
@@ -71,14 +95,29 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
     }
 
     /**
-     * IP address that this address is routed to.
+     * Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     * 
+     */
+    @Import(name="retainOnDelete")
+    private @Nullable Output<Boolean> retainOnDelete;
+
+    /**
+     * @return Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     * 
+     */
+    public Optional<Output<Boolean>> retainOnDelete() {
+        return Optional.ofNullable(this.retainOnDelete);
+    }
+
+    /**
+     * IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      * 
      */
     @Import(name="routedTo")
     private @Nullable Output<String> routedTo;
 
     /**
-     * @return IP address that this address is routed to.
+     * @return IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      * 
      */
     public Optional<Output<String>> routedTo() {
@@ -86,14 +125,14 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
     }
 
     /**
-     * IP address tags.
+     * IP address tags. Removing them clears all tags.
      * 
      */
     @Import(name="tags")
     private @Nullable Output<Map<String,String>> tags;
 
     /**
-     * @return IP address tags.
+     * @return IP address tags. Removing them clears all tags.
      * 
      */
     public Optional<Output<Map<String,String>>> tags() {
@@ -101,14 +140,14 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
     }
 
     /**
-     * Server that this address is targeted to.
+     * Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      * 
      */
     @Import(name="targetedTo")
     private @Nullable Output<Integer> targetedTo;
 
     /**
-     * @return Server that this address is targeted to.
+     * @return Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      * 
      */
     public Optional<Output<Integer>> targetedTo() {
@@ -119,9 +158,11 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
 
     private IPArgs(IPArgs $) {
         this.aRecord = $.aRecord;
+        this.deletionProtection = $.deletionProtection;
         this.project = $.project;
         this.ptrRecord = $.ptrRecord;
         this.region = $.region;
+        this.retainOnDelete = $.retainOnDelete;
         this.routedTo = $.routedTo;
         this.tags = $.tags;
         this.targetedTo = $.targetedTo;
@@ -146,7 +187,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param aRecord IP address A record.
+         * @param aRecord IP address A record. Removing it clears the record.
          * 
          * @return builder
          * 
@@ -157,7 +198,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param aRecord IP address A record.
+         * @param aRecord IP address A record. Removing it clears the record.
          * 
          * @return builder
          * 
@@ -166,17 +207,50 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
             return aRecord(Output.of(aRecord));
         }
 
+        /**
+         * @param deletionProtection Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder deletionProtection(@Nullable Output<Boolean> deletionProtection) {
+            $.deletionProtection = deletionProtection;
+            return this;
+        }
+
+        /**
+         * @param deletionProtection Whether the IP address can&#39;t be deleted or replaced. It has to be disabled in an update before the address can be released.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder deletionProtection(Boolean deletionProtection) {
+            return deletionProtection(Output.of(deletionProtection));
+        }
+
+        /**
+         * @param project IP address project ID.
+         * 
+         * @return builder
+         * 
+         */
         public Builder project(Output<Integer> project) {
             $.project = project;
             return this;
         }
 
+        /**
+         * @param project IP address project ID.
+         * 
+         * @return builder
+         * 
+         */
         public Builder project(Integer project) {
             return project(Output.of(project));
         }
 
         /**
-         * @param ptrRecord IP address PTR record.
+         * @param ptrRecord IP address PTR record. Removing it clears the record.
          * 
          * @return builder
          * 
@@ -187,7 +261,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param ptrRecord IP address PTR record.
+         * @param ptrRecord IP address PTR record. Removing it clears the record.
          * 
          * @return builder
          * 
@@ -197,7 +271,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param region IP address project ID.
+         * @param region IP address region slug.
          * 
          * @return builder
          * 
@@ -208,7 +282,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param region IP address project ID.
+         * @param region IP address region slug.
          * 
          * @return builder
          * 
@@ -218,7 +292,28 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param routedTo IP address that this address is routed to.
+         * @param retainOnDelete Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder retainOnDelete(@Nullable Output<Boolean> retainOnDelete) {
+            $.retainOnDelete = retainOnDelete;
+            return this;
+        }
+
+        /**
+         * @param retainOnDelete Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+         * 
+         * @return builder
+         * 
+         */
+        public Builder retainOnDelete(Boolean retainOnDelete) {
+            return retainOnDelete(Output.of(retainOnDelete));
+        }
+
+        /**
+         * @param routedTo IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
          * 
          * @return builder
          * 
@@ -229,7 +324,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param routedTo IP address that this address is routed to.
+         * @param routedTo IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
          * 
          * @return builder
          * 
@@ -239,7 +334,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param tags IP address tags.
+         * @param tags IP address tags. Removing them clears all tags.
          * 
          * @return builder
          * 
@@ -250,7 +345,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param tags IP address tags.
+         * @param tags IP address tags. Removing them clears all tags.
          * 
          * @return builder
          * 
@@ -260,7 +355,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param targetedTo Server that this address is targeted to.
+         * @param targetedTo Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
          * 
          * @return builder
          * 
@@ -271,7 +366,7 @@ public final class IPArgs extends com.pulumi.resources.ResourceArgs {
         }
 
         /**
-         * @param targetedTo Server that this address is targeted to.
+         * @param targetedTo Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
          * 
          * @return builder
          * 
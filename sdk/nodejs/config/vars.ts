@@ -7,6 +7,39 @@ import * as utilities from "../utilities";
 declare var exports: any;
 const __config = new pulumi.Config("pulumi-cherry-servers");
 
+/**
+ * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+ */
+export declare const auditLog: string | undefined;
+Object.defineProperty(exports, "auditLog", {
+    get() {
+        return __config.get("auditLog");
+    },
+    enumerable: true,
+});
+
+/**
+ * Maximum number of API requests that can be made at once, before rate limiting kicks in.
+ */
+export declare const burst: number;
+Object.defineProperty(exports, "burst", {
+    get() {
+        return __config.getObject<number>("burst") ?? 10;
+    },
+    enumerable: true,
+});
+
+/**
+ * Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+ */
+export declare const requestsPerSecond: number;
+Object.defineProperty(exports, "requestsPerSecond", {
+    get() {
+        return __config.getObject<number>("requestsPerSecond") ?? 5;
+    },
+    enumerable: true,
+});
+
 /**
  * Cherry Servers API token.
  */
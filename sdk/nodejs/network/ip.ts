@@ -21,7 +21,7 @@ export class IP extends pulumi.CustomResource {
     }
 
     /** @internal */
-    public static readonly __pulumiType = 'pulumi-cherry-servers:provider:IP';
+    public static readonly __pulumiType = 'pulumi-cherry-servers:network:IP';
 
     /**
      * Returns true if the given object is an instance of IP.  This is designed to work even
@@ -35,7 +35,7 @@ export class IP extends pulumi.CustomResource {
     }
 
     /**
-     * IP address A record.
+     * IP address A record. Removing it clears the record.
      */
     declare public readonly aRecord: pulumi.Output<string | undefined>;
     /**
@@ -50,25 +50,40 @@ export class IP extends pulumi.CustomResource {
      * IP address CIDR.
      */
     declare public /*out*/ readonly cidr: pulumi.Output<string>;
+    /**
+     * Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+     */
+    declare public readonly deletionProtection: pulumi.Output<boolean | undefined>;
+    /**
+     * IP address project ID.
+     */
     declare public readonly project: pulumi.Output<number>;
     /**
-     * IP address PTR record.
+     * IP address PTR record. Removing it clears the record.
      */
     declare public readonly ptrRecord: pulumi.Output<string | undefined>;
     /**
-     * IP address project ID.
+     * IP address region slug.
      */
     declare public readonly region: pulumi.Output<string>;
     /**
-     * IP address that this address is routed to.
+     * Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     */
+    declare public readonly retainOnDelete: pulumi.Output<boolean | undefined>;
+    /**
+     * IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      */
     declare public readonly routedTo: pulumi.Output<string | undefined>;
     /**
-     * IP address tags.
+     * Version of the state layout, used to upgrade state written by older provider versions.
+     */
+    declare public /*out*/ readonly stateVersion: pulumi.Output<number | undefined>;
+    /**
+     * IP address tags. Removing them clears all tags.
      */
     declare public readonly tags: pulumi.Output<{[key: string]: string} | undefined>;
     /**
-     * Server that this address is targeted to.
+     * Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      */
     declare public readonly targetedTo: pulumi.Output<number | undefined>;
     /**
@@ -94,30 +109,38 @@ export class IP extends pulumi.CustomResource {
                 throw new Error("Missing required property 'region'");
             }
             resourceInputs["aRecord"] = args?.aRecord;
+            resourceInputs["deletionProtection"] = args?.deletionProtection;
             resourceInputs["project"] = args?.project;
             resourceInputs["ptrRecord"] = args?.ptrRecord;
             resourceInputs["region"] = args?.region;
+            resourceInputs["retainOnDelete"] = args?.retainOnDelete;
             resourceInputs["routedTo"] = args?.routedTo;
             resourceInputs["tags"] = args?.tags;
             resourceInputs["targetedTo"] = args?.targetedTo;
             resourceInputs["address"] = undefined /*out*/;
             resourceInputs["addressFamily"] = undefined /*out*/;
             resourceInputs["cidr"] = undefined /*out*/;
+            resourceInputs["stateVersion"] = undefined /*out*/;
             resourceInputs["type"] = undefined /*out*/;
         } else {
             resourceInputs["aRecord"] = undefined /*out*/;
             resourceInputs["address"] = undefined /*out*/;
             resourceInputs["addressFamily"] = undefined /*out*/;
             resourceInputs["cidr"] = undefined /*out*/;
+            resourceInputs["deletionProtection"] = undefined /*out*/;
             resourceInputs["project"] = undefined /*out*/;
             resourceInputs["ptrRecord"] = undefined /*out*/;
             resourceInputs["region"] = undefined /*out*/;
+            resourceInputs["retainOnDelete"] = undefined /*out*/;
             resourceInputs["routedTo"] = undefined /*out*/;
+            resourceInputs["stateVersion"] = undefined /*out*/;
             resourceInputs["tags"] = undefined /*out*/;
             resourceInputs["targetedTo"] = undefined /*out*/;
             resourceInputs["type"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
+        const aliasOpts = { aliases: [{ type: "pulumi-cherry-servers:provider:IP" }] };
+        opts = pulumi.mergeOptions(opts, aliasOpts);
         super(IP.__pulumiType, name, resourceInputs, opts);
     }
 }
@@ -127,28 +150,39 @@ export class IP extends pulumi.CustomResource {
  */
 export interface IPArgs {
     /**
-     * IP address A record.
+     * IP address A record. Removing it clears the record.
      */
     aRecord?: pulumi.Input<string>;
+    /**
+     * Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+     */
+    deletionProtection?: pulumi.Input<boolean>;
+    /**
+     * IP address project ID.
+     */
     project: pulumi.Input<number>;
     /**
-     * IP address PTR record.
+     * IP address PTR record. Removing it clears the record.
      */
     ptrRecord?: pulumi.Input<string>;
     /**
-     * IP address project ID.
+     * IP address region slug.
      */
     region: pulumi.Input<string>;
     /**
-     * IP address that this address is routed to.
+     * Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+     */
+    retainOnDelete?: pulumi.Input<boolean>;
+    /**
+     * IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
      */
     routedTo?: pulumi.Input<string>;
     /**
-     * IP address tags.
+     * IP address tags. Removing them clears all tags.
      */
     tags?: pulumi.Input<{[key: string]: pulumi.Input<string>}>;
     /**
-     * Server that this address is targeted to.
+     * Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
      */
     targetedTo?: pulumi.Input<number>;
 }
@@ -2,7 +2,7 @@
 // *** Do not edit by hand unless you're certain you know what you are doing! ***
 
 import * as pulumi from "@pulumi/pulumi";
-import * as utilities from "../utilities";
+import * as utilities from "./utilities";
 
 /**
  * A Cherry Servers project.
@@ -21,7 +21,7 @@ export class Project extends pulumi.CustomResource {
     }
 
     /** @internal */
-    public static readonly __pulumiType = 'pulumi-cherry-servers:provider:Project';
+    public static readonly __pulumiType = 'pulumi-cherry-servers:index:Project';
 
     /**
      * Returns true if the given object is an instance of Project.  This is designed to work even
@@ -35,15 +35,23 @@ export class Project extends pulumi.CustomResource {
     }
 
     /**
-     * Whether BGP should be enabled for the project.
+     * Whether BGP should be enabled for the project. Removing it disables BGP.
      */
     declare public readonly bgp: pulumi.Output<boolean | undefined>;
+    /**
+     * Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     */
+    declare public readonly deletionProtection: pulumi.Output<boolean | undefined>;
+    /**
+     * Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+     */
+    declare public readonly forceDestroy: pulumi.Output<boolean | undefined>;
     /**
      * LocalASN assigned to the project.
      */
     declare public /*out*/ readonly localASN: pulumi.Output<number | undefined>;
     /**
-     * Project name.
+     * Project name. If removed, the current name is kept.
      */
     declare public readonly name: pulumi.Output<string | undefined>;
     /**
@@ -66,16 +74,22 @@ export class Project extends pulumi.CustomResource {
                 throw new Error("Missing required property 'team'");
             }
             resourceInputs["bgp"] = args?.bgp;
+            resourceInputs["deletionProtection"] = args?.deletionProtection;
+            resourceInputs["forceDestroy"] = args?.forceDestroy;
             resourceInputs["name"] = args?.name;
             resourceInputs["team"] = args?.team;
             resourceInputs["localASN"] = undefined /*out*/;
         } else {
             resourceInputs["bgp"] = undefined /*out*/;
+            resourceInputs["deletionProtection"] = undefined /*out*/;
+            resourceInputs["forceDestroy"] = undefined /*out*/;
             resourceInputs["localASN"] = undefined /*out*/;
             resourceInputs["name"] = undefined /*out*/;
             resourceInputs["team"] = undefined /*out*/;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
+        const aliasOpts = { aliases: [{ type: "pulumi-cherry-servers:provider:Project" }] };
+        opts = pulumi.mergeOptions(opts, aliasOpts);
         super(Project.__pulumiType, name, resourceInputs, opts);
     }
 }
@@ -85,11 +99,19 @@ export class Project extends pulumi.CustomResource {
  */
 export interface ProjectArgs {
     /**
-     * Whether BGP should be enabled for the project.
+     * Whether BGP should be enabled for the project. Removing it disables BGP.
      */
     bgp?: pulumi.Input<boolean>;
     /**
-     * Project name.
+     * Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+     */
+    deletionProtection?: pulumi.Input<boolean>;
+    /**
+     * Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+     */
+    forceDestroy?: pulumi.Input<boolean>;
+    /**
+     * Project name. If removed, the current name is kept.
      */
     name?: pulumi.Input<string>;
     /**
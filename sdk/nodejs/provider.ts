@@ -19,6 +19,10 @@ export class Provider extends pulumi.ProviderResource {
         return obj['__pulumiType'] === "pulumi:providers:" + Provider.__pulumiType;
     }
 
+    /**
+     * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     */
+    declare public readonly auditLog: pulumi.Output<string | undefined>;
     /**
      * Cherry Servers API token.
      */
@@ -38,6 +42,9 @@ export class Provider extends pulumi.ProviderResource {
             if (args?.token === undefined && !opts.urn) {
                 throw new Error("Missing required property 'token'");
             }
+            resourceInputs["auditLog"] = args?.auditLog;
+            resourceInputs["burst"] = pulumi.output((args?.burst) ?? 10).apply(JSON.stringify);
+            resourceInputs["requestsPerSecond"] = pulumi.output((args?.requestsPerSecond) ?? 5).apply(JSON.stringify);
             resourceInputs["token"] = args?.token ? pulumi.secret(args.token) : undefined;
         }
         opts = pulumi.mergeOptions(utilities.resourceOptsDefaults(), opts);
@@ -51,6 +58,18 @@ export class Provider extends pulumi.ProviderResource {
  * The set of arguments for constructing a Provider resource.
  */
 export interface ProviderArgs {
+    /**
+     * Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+     */
+    auditLog?: pulumi.Input<string>;
+    /**
+     * Maximum number of API requests that can be made at once, before rate limiting kicks in.
+     */
+    burst?: pulumi.Input<number>;
+    /**
+     * Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+     */
+    requestsPerSecond?: pulumi.Input<number>;
     /**
      * Cherry Servers API token.
      */
@@ -6,28 +6,36 @@ import builtins as _builtins
 from . import _utilities
 import typing
 # Export this package's modules as members:
+from .project import *
 from .provider import *
 
 # Make subpackages available:
 if typing.TYPE_CHECKING:
     import caliban0_pulumi_cherry_servers.config as __config
     config = __config
-    import caliban0_pulumi_cherry_servers.provider as __provider
-    provider = __provider
+    import caliban0_pulumi_cherry_servers.network as __network
+    network = __network
 else:
     config = _utilities.lazy_import('caliban0_pulumi_cherry_servers.config')
-    provider = _utilities.lazy_import('caliban0_pulumi_cherry_servers.provider')
+    network = _utilities.lazy_import('caliban0_pulumi_cherry_servers.network')
 
 _utilities.register(
     resource_modules="""
 [
  {
   "pkg": "pulumi-cherry-servers",
-  "mod": "provider",
-  "fqn": "caliban0_pulumi_cherry_servers.provider",
+  "mod": "index",
+  "fqn": "caliban0_pulumi_cherry_servers",
+  "classes": {
+   "pulumi-cherry-servers:index:Project": "Project"
+  }
+ },
+ {
+  "pkg": "pulumi-cherry-servers",
+  "mod": "network",
+  "fqn": "caliban0_pulumi_cherry_servers.network",
   "classes": {
-   "pulumi-cherry-servers:provider:IP": "IP",
-   "pulumi-cherry-servers:provider:Project": "Project"
+   "pulumi-cherry-servers:network:IP": "IP"
   }
  }
 ]
@@ -14,6 +14,21 @@ else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
 from .. import _utilities
 
+auditLog: Optional[str]
+"""
+Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+"""
+
+burst: int
+"""
+Maximum number of API requests that can be made at once, before rate limiting kicks in.
+"""
+
+requestsPerSecond: float
+"""
+Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+"""
+
 token: Optional[str]
 """
 Cherry Servers API token.
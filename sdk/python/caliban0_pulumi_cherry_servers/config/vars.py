@@ -20,6 +20,27 @@ __config__ = pulumi.Config('pulumi-cherry-servers')
 
 
 class _ExportableConfig(types.ModuleType):
+    @_builtins.property
+    def audit_log(self) -> Optional[str]:
+        """
+        Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        """
+        return __config__.get('auditLog')
+
+    @_builtins.property
+    def burst(self) -> int:
+        """
+        Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        """
+        return __config__.get_int('burst') or 10
+
+    @_builtins.property
+    def requests_per_second(self) -> float:
+        """
+        Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+        """
+        return __config__.get_float('requestsPerSecond') or 5
+
     @_builtins.property
     def token(self) -> Optional[str]:
         """
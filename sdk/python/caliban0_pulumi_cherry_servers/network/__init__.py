@@ -7,4 +7,3 @@ from .. import _utilities
 import typing
 # Export this package's modules as members:
 from .ip import *
-from .project import *
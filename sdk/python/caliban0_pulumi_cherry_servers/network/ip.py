@@ -22,25 +22,34 @@ class IPArgs:
                  project: pulumi.Input[_builtins.int],
                  region: pulumi.Input[_builtins.str],
                  a_record: Optional[pulumi.Input[_builtins.str]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
                  ptr_record: Optional[pulumi.Input[_builtins.str]] = None,
+                 retain_on_delete: Optional[pulumi.Input[_builtins.bool]] = None,
                  routed_to: Optional[pulumi.Input[_builtins.str]] = None,
                  tags: Optional[pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
                  targeted_to: Optional[pulumi.Input[_builtins.int]] = None):
         """
         The set of arguments for constructing a IP resource.
-        :param pulumi.Input[_builtins.str] region: IP address project ID.
-        :param pulumi.Input[_builtins.str] a_record: IP address A record.
-        :param pulumi.Input[_builtins.str] ptr_record: IP address PTR record.
-        :param pulumi.Input[_builtins.str] routed_to: IP address that this address is routed to.
-        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] tags: IP address tags.
-        :param pulumi.Input[_builtins.int] targeted_to: Server that this address is targeted to.
+        :param pulumi.Input[_builtins.int] project: IP address project ID.
+        :param pulumi.Input[_builtins.str] region: IP address region slug.
+        :param pulumi.Input[_builtins.str] a_record: IP address A record. Removing it clears the record.
+        :param pulumi.Input[_builtins.bool] deletion_protection: Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        :param pulumi.Input[_builtins.str] ptr_record: IP address PTR record. Removing it clears the record.
+        :param pulumi.Input[_builtins.bool] retain_on_delete: Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        :param pulumi.Input[_builtins.str] routed_to: IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] tags: IP address tags. Removing them clears all tags.
+        :param pulumi.Input[_builtins.int] targeted_to: Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         """
         pulumi.set(__self__, "project", project)
         pulumi.set(__self__, "region", region)
         if a_record is not None:
             pulumi.set(__self__, "a_record", a_record)
+        if deletion_protection is not None:
+            pulumi.set(__self__, "deletion_protection", deletion_protection)
         if ptr_record is not None:
             pulumi.set(__self__, "ptr_record", ptr_record)
+        if retain_on_delete is not None:
+            pulumi.set(__self__, "retain_on_delete", retain_on_delete)
         if routed_to is not None:
             pulumi.set(__self__, "routed_to", routed_to)
         if tags is not None:
@@ -51,6 +60,9 @@ class IPArgs:
     @_builtins.property
     @pulumi.getter
     def project(self) -> pulumi.Input[_builtins.int]:
+        """
+        IP address project ID.
+        """
         return pulumi.get(self, "project")
 
     @project.setter
@@ -61,7 +73,7 @@ class IPArgs:
     @pulumi.getter
     def region(self) -> pulumi.Input[_builtins.str]:
         """
-        IP address project ID.
+        IP address region slug.
         """
         return pulumi.get(self, "region")
 
@@ -73,7 +85,7 @@ class IPArgs:
     @pulumi.getter(name="aRecord")
     def a_record(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        IP address A record.
+        IP address A record. Removing it clears the record.
         """
         return pulumi.get(self, "a_record")
 
@@ -81,11 +93,23 @@ class IPArgs:
     def a_record(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "a_record", value)
 
+    @_builtins.property
+    @pulumi.getter(name="deletionProtection")
+    def deletion_protection(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        """
+        return pulumi.get(self, "deletion_protection")
+
+    @deletion_protection.setter
+    def deletion_protection(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "deletion_protection", value)
+
     @_builtins.property
     @pulumi.getter(name="ptrRecord")
     def ptr_record(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        IP address PTR record.
+        IP address PTR record. Removing it clears the record.
         """
         return pulumi.get(self, "ptr_record")
 
@@ -93,11 +117,23 @@ class IPArgs:
     def ptr_record(self, value: Optional[pulumi.Input[_builtins.str]]):
         pulumi.set(self, "ptr_record", value)
 
+    @_builtins.property
+    @pulumi.getter(name="retainOnDelete")
+    def retain_on_delete(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        """
+        return pulumi.get(self, "retain_on_delete")
+
+    @retain_on_delete.setter
+    def retain_on_delete(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "retain_on_delete", value)
+
     @_builtins.property
     @pulumi.getter(name="routedTo")
     def routed_to(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        IP address that this address is routed to.
+        IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
         """
         return pulumi.get(self, "routed_to")
 
@@ -109,7 +145,7 @@ class IPArgs:
     @pulumi.getter
     def tags(self) -> Optional[pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]]]:
         """
-        IP address tags.
+        IP address tags. Removing them clears all tags.
         """
         return pulumi.get(self, "tags")
 
@@ -121,7 +157,7 @@ class IPArgs:
     @pulumi.getter(name="targetedTo")
     def targeted_to(self) -> Optional[pulumi.Input[_builtins.int]]:
         """
-        Server that this address is targeted to.
+        Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         """
         return pulumi.get(self, "targeted_to")
 
@@ -130,16 +166,18 @@ class IPArgs:
         pulumi.set(self, "targeted_to", value)
 
 
-@pulumi.type_token("pulumi-cherry-servers:provider:IP")
+@pulumi.type_token("pulumi-cherry-servers:network:IP")
 class IP(pulumi.CustomResource):
     @overload
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  a_record: Optional[pulumi.Input[_builtins.str]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
                  project: Optional[pulumi.Input[_builtins.int]] = None,
                  ptr_record: Optional[pulumi.Input[_builtins.str]] = None,
                  region: Optional[pulumi.Input[_builtins.str]] = None,
+                 retain_on_delete: Optional[pulumi.Input[_builtins.bool]] = None,
                  routed_to: Optional[pulumi.Input[_builtins.str]] = None,
                  tags: Optional[pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
                  targeted_to: Optional[pulumi.Input[_builtins.int]] = None,
@@ -149,12 +187,15 @@ class IP(pulumi.CustomResource):
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
-        :param pulumi.Input[_builtins.str] a_record: IP address A record.
-        :param pulumi.Input[_builtins.str] ptr_record: IP address PTR record.
-        :param pulumi.Input[_builtins.str] region: IP address project ID.
-        :param pulumi.Input[_builtins.str] routed_to: IP address that this address is routed to.
-        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] tags: IP address tags.
-        :param pulumi.Input[_builtins.int] targeted_to: Server that this address is targeted to.
+        :param pulumi.Input[_builtins.str] a_record: IP address A record. Removing it clears the record.
+        :param pulumi.Input[_builtins.bool] deletion_protection: Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        :param pulumi.Input[_builtins.int] project: IP address project ID.
+        :param pulumi.Input[_builtins.str] ptr_record: IP address PTR record. Removing it clears the record.
+        :param pulumi.Input[_builtins.str] region: IP address region slug.
+        :param pulumi.Input[_builtins.bool] retain_on_delete: Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        :param pulumi.Input[_builtins.str] routed_to: IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
+        :param pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]] tags: IP address tags. Removing them clears all tags.
+        :param pulumi.Input[_builtins.int] targeted_to: Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         """
         ...
     @overload
@@ -181,9 +222,11 @@ class IP(pulumi.CustomResource):
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  a_record: Optional[pulumi.Input[_builtins.str]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
                  project: Optional[pulumi.Input[_builtins.int]] = None,
                  ptr_record: Optional[pulumi.Input[_builtins.str]] = None,
                  region: Optional[pulumi.Input[_builtins.str]] = None,
+                 retain_on_delete: Optional[pulumi.Input[_builtins.bool]] = None,
                  routed_to: Optional[pulumi.Input[_builtins.str]] = None,
                  tags: Optional[pulumi.Input[Mapping[str, pulumi.Input[_builtins.str]]]] = None,
                  targeted_to: Optional[pulumi.Input[_builtins.int]] = None,
@@ -197,6 +240,7 @@ class IP(pulumi.CustomResource):
             __props__ = IPArgs.__new__(IPArgs)
 
             __props__.__dict__["a_record"] = a_record
+            __props__.__dict__["deletion_protection"] = deletion_protection
             if project is None and not opts.urn:
                 raise TypeError("Missing required property 'project'")
             __props__.__dict__["project"] = project
@@ -204,15 +248,19 @@ class IP(pulumi.CustomResource):
             if region is None and not opts.urn:
                 raise TypeError("Missing required property 'region'")
             __props__.__dict__["region"] = region
+            __props__.__dict__["retain_on_delete"] = retain_on_delete
             __props__.__dict__["routed_to"] = routed_to
             __props__.__dict__["tags"] = tags
             __props__.__dict__["targeted_to"] = targeted_to
             __props__.__dict__["address"] = None
             __props__.__dict__["address_family"] = None
             __props__.__dict__["cidr"] = None
+            __props__.__dict__["state_version"] = None
             __props__.__dict__["type"] = None
+        alias_opts = pulumi.ResourceOptions(aliases=[pulumi.Alias(type_="pulumi-cherry-servers:provider:IP")])
+        opts = pulumi.ResourceOptions.merge(opts, alias_opts)
         super(IP, __self__).__init__(
-            'pulumi-cherry-servers:provider:IP',
+            'pulumi-cherry-servers:network:IP',
             resource_name,
             __props__,
             opts)
@@ -237,10 +285,13 @@ class IP(pulumi.CustomResource):
         __props__.__dict__["address"] = None
         __props__.__dict__["address_family"] = None
         __props__.__dict__["cidr"] = None
+        __props__.__dict__["deletion_protection"] = None
         __props__.__dict__["project"] = None
         __props__.__dict__["ptr_record"] = None
         __props__.__dict__["region"] = None
+        __props__.__dict__["retain_on_delete"] = None
         __props__.__dict__["routed_to"] = None
+        __props__.__dict__["state_version"] = None
         __props__.__dict__["tags"] = None
         __props__.__dict__["targeted_to"] = None
         __props__.__dict__["type"] = None
@@ -250,7 +301,7 @@ class IP(pulumi.CustomResource):
     @pulumi.getter(name="aRecord")
     def a_record(self) -> pulumi.Output[Optional[_builtins.str]]:
         """
-        IP address A record.
+        IP address A record. Removing it clears the record.
         """
         return pulumi.get(self, "a_record")
 
@@ -278,16 +329,27 @@ class IP(pulumi.CustomResource):
         """
         return pulumi.get(self, "cidr")
 
+    @_builtins.property
+    @pulumi.getter(name="deletionProtection")
+    def deletion_protection(self) -> pulumi.Output[Optional[_builtins.bool]]:
+        """
+        Whether the IP address can't be deleted or replaced. It has to be disabled in an update before the address can be released.
+        """
+        return pulumi.get(self, "deletion_protection")
+
     @_builtins.property
     @pulumi.getter
     def project(self) -> pulumi.Output[_builtins.int]:
+        """
+        IP address project ID.
+        """
         return pulumi.get(self, "project")
 
     @_builtins.property
     @pulumi.getter(name="ptrRecord")
     def ptr_record(self) -> pulumi.Output[Optional[_builtins.str]]:
         """
-        IP address PTR record.
+        IP address PTR record. Removing it clears the record.
         """
         return pulumi.get(self, "ptr_record")
 
@@ -295,23 +357,39 @@ class IP(pulumi.CustomResource):
     @pulumi.getter
     def region(self) -> pulumi.Output[_builtins.str]:
         """
-        IP address project ID.
+        IP address region slug.
         """
         return pulumi.get(self, "region")
 
+    @_builtins.property
+    @pulumi.getter(name="retainOnDelete")
+    def retain_on_delete(self) -> pulumi.Output[Optional[_builtins.bool]]:
+        """
+        Whether deleting the resource keeps the IP address, instead of releasing it. The address is unassigned and tagged with pulumi-retained, so that it can be found and imported later.
+        """
+        return pulumi.get(self, "retain_on_delete")
+
     @_builtins.property
     @pulumi.getter(name="routedTo")
     def routed_to(self) -> pulumi.Output[Optional[_builtins.str]]:
         """
-        IP address that this address is routed to.
+        IP address that this address is routed to. Conflicts with targetedTo. Removing both unassigns the address.
         """
         return pulumi.get(self, "routed_to")
 
+    @_builtins.property
+    @pulumi.getter(name="stateVersion")
+    def state_version(self) -> pulumi.Output[Optional[_builtins.int]]:
+        """
+        Version of the state layout, used to upgrade state written by older provider versions.
+        """
+        return pulumi.get(self, "state_version")
+
     @_builtins.property
     @pulumi.getter
     def tags(self) -> pulumi.Output[Optional[Mapping[str, _builtins.str]]]:
         """
-        IP address tags.
+        IP address tags. Removing them clears all tags.
         """
         return pulumi.get(self, "tags")
 
@@ -319,7 +397,7 @@ class IP(pulumi.CustomResource):
     @pulumi.getter(name="targetedTo")
     def targeted_to(self) -> pulumi.Output[Optional[_builtins.int]]:
         """
-        Server that this address is targeted to.
+        Server that this address is targeted to. Conflicts with routedTo. Removing both unassigns the address.
         """
         return pulumi.get(self, "targeted_to")
 
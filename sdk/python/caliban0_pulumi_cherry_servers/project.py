@@ -12,7 +12,7 @@ if sys.version_info >= (3, 11):
     from typing import NotRequired, TypedDict, TypeAlias
 else:
     from typing_extensions import NotRequired, TypedDict, TypeAlias
-from .. import _utilities
+from . import _utilities
 
 __all__ = ['ProjectArgs', 'Project']
 
@@ -21,16 +21,24 @@ class ProjectArgs:
     def __init__(__self__, *,
                  team: pulumi.Input[_builtins.int],
                  bgp: Optional[pulumi.Input[_builtins.bool]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
+                 force_destroy: Optional[pulumi.Input[_builtins.bool]] = None,
                  name: Optional[pulumi.Input[_builtins.str]] = None):
         """
         The set of arguments for constructing a Project resource.
         :param pulumi.Input[_builtins.int] team: ID of the team the project belongs to.
-        :param pulumi.Input[_builtins.bool] bgp: Whether BGP should be enabled for the project.
-        :param pulumi.Input[_builtins.str] name: Project name.
+        :param pulumi.Input[_builtins.bool] bgp: Whether BGP should be enabled for the project. Removing it disables BGP.
+        :param pulumi.Input[_builtins.bool] deletion_protection: Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        :param pulumi.Input[_builtins.bool] force_destroy: Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        :param pulumi.Input[_builtins.str] name: Project name. If removed, the current name is kept.
         """
         pulumi.set(__self__, "team", team)
         if bgp is not None:
             pulumi.set(__self__, "bgp", bgp)
+        if deletion_protection is not None:
+            pulumi.set(__self__, "deletion_protection", deletion_protection)
+        if force_destroy is not None:
+            pulumi.set(__self__, "force_destroy", force_destroy)
         if name is not None:
             pulumi.set(__self__, "name", name)
 
@@ -50,7 +58,7 @@ class ProjectArgs:
     @pulumi.getter
     def bgp(self) -> Optional[pulumi.Input[_builtins.bool]]:
         """
-        Whether BGP should be enabled for the project.
+        Whether BGP should be enabled for the project. Removing it disables BGP.
         """
         return pulumi.get(self, "bgp")
 
@@ -58,11 +66,35 @@ class ProjectArgs:
     def bgp(self, value: Optional[pulumi.Input[_builtins.bool]]):
         pulumi.set(self, "bgp", value)
 
+    @_builtins.property
+    @pulumi.getter(name="deletionProtection")
+    def deletion_protection(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        """
+        return pulumi.get(self, "deletion_protection")
+
+    @deletion_protection.setter
+    def deletion_protection(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "deletion_protection", value)
+
+    @_builtins.property
+    @pulumi.getter(name="forceDestroy")
+    def force_destroy(self) -> Optional[pulumi.Input[_builtins.bool]]:
+        """
+        Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        """
+        return pulumi.get(self, "force_destroy")
+
+    @force_destroy.setter
+    def force_destroy(self, value: Optional[pulumi.Input[_builtins.bool]]):
+        pulumi.set(self, "force_destroy", value)
+
     @_builtins.property
     @pulumi.getter
     def name(self) -> Optional[pulumi.Input[_builtins.str]]:
         """
-        Project name.
+        Project name. If removed, the current name is kept.
         """
         return pulumi.get(self, "name")
 
@@ -71,13 +103,15 @@ class ProjectArgs:
         pulumi.set(self, "name", value)
 
 
-@pulumi.type_token("pulumi-cherry-servers:provider:Project")
+@pulumi.type_token("pulumi-cherry-servers:index:Project")
 class Project(pulumi.CustomResource):
     @overload
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  bgp: Optional[pulumi.Input[_builtins.bool]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
+                 force_destroy: Optional[pulumi.Input[_builtins.bool]] = None,
                  name: Optional[pulumi.Input[_builtins.str]] = None,
                  team: Optional[pulumi.Input[_builtins.int]] = None,
                  __props__=None):
@@ -86,8 +120,10 @@ class Project(pulumi.CustomResource):
 
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
-        :param pulumi.Input[_builtins.bool] bgp: Whether BGP should be enabled for the project.
-        :param pulumi.Input[_builtins.str] name: Project name.
+        :param pulumi.Input[_builtins.bool] bgp: Whether BGP should be enabled for the project. Removing it disables BGP.
+        :param pulumi.Input[_builtins.bool] deletion_protection: Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        :param pulumi.Input[_builtins.bool] force_destroy: Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        :param pulumi.Input[_builtins.str] name: Project name. If removed, the current name is kept.
         :param pulumi.Input[_builtins.int] team: ID of the team the project belongs to.
         """
         ...
@@ -115,6 +151,8 @@ class Project(pulumi.CustomResource):
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
                  bgp: Optional[pulumi.Input[_builtins.bool]] = None,
+                 deletion_protection: Optional[pulumi.Input[_builtins.bool]] = None,
+                 force_destroy: Optional[pulumi.Input[_builtins.bool]] = None,
                  name: Optional[pulumi.Input[_builtins.str]] = None,
                  team: Optional[pulumi.Input[_builtins.int]] = None,
                  __props__=None):
@@ -127,13 +165,17 @@ class Project(pulumi.CustomResource):
             __props__ = ProjectArgs.__new__(ProjectArgs)
 
             __props__.__dict__["bgp"] = bgp
+            __props__.__dict__["deletion_protection"] = deletion_protection
+            __props__.__dict__["force_destroy"] = force_destroy
             __props__.__dict__["name"] = name
             if team is None and not opts.urn:
                 raise TypeError("Missing required property 'team'")
             __props__.__dict__["team"] = team
             __props__.__dict__["local_asn"] = None
+        alias_opts = pulumi.ResourceOptions(aliases=[pulumi.Alias(type_="pulumi-cherry-servers:provider:Project")])
+        opts = pulumi.ResourceOptions.merge(opts, alias_opts)
         super(Project, __self__).__init__(
-            'pulumi-cherry-servers:provider:Project',
+            'pulumi-cherry-servers:index:Project',
             resource_name,
             __props__,
             opts)
@@ -155,6 +197,8 @@ class Project(pulumi.CustomResource):
         __props__ = ProjectArgs.__new__(ProjectArgs)
 
         __props__.__dict__["bgp"] = None
+        __props__.__dict__["deletion_protection"] = None
+        __props__.__dict__["force_destroy"] = None
         __props__.__dict__["local_asn"] = None
         __props__.__dict__["name"] = None
         __props__.__dict__["team"] = None
@@ -164,10 +208,26 @@ class Project(pulumi.CustomResource):
     @pulumi.getter
     def bgp(self) -> pulumi.Output[Optional[_builtins.bool]]:
         """
-        Whether BGP should be enabled for the project.
+        Whether BGP should be enabled for the project. Removing it disables BGP.
         """
         return pulumi.get(self, "bgp")
 
+    @_builtins.property
+    @pulumi.getter(name="deletionProtection")
+    def deletion_protection(self) -> pulumi.Output[Optional[_builtins.bool]]:
+        """
+        Whether the project can't be deleted or replaced. It has to be disabled in an update before the project can be deleted.
+        """
+        return pulumi.get(self, "deletion_protection")
+
+    @_builtins.property
+    @pulumi.getter(name="forceDestroy")
+    def force_destroy(self) -> pulumi.Output[Optional[_builtins.bool]]:
+        """
+        Whether deleting the project also deletes the servers, IP addresses and volumes left in it. Otherwise, deleting a project that isn't empty fails. It has to be enabled in an update before the delete.
+        """
+        return pulumi.get(self, "force_destroy")
+
     @_builtins.property
     @pulumi.getter(name="localASN")
     def local_asn(self) -> pulumi.Output[Optional[_builtins.int]]:
@@ -180,7 +240,7 @@ class Project(pulumi.CustomResource):
     @pulumi.getter
     def name(self) -> pulumi.Output[Optional[_builtins.str]]:
         """
-        Project name.
+        Project name. If removed, the current name is kept.
         """
         return pulumi.get(self, "name")
 
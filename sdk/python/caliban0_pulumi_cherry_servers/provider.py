@@ -19,12 +19,28 @@ __all__ = ['ProviderArgs', 'Provider']
 @pulumi.input_type
 class ProviderArgs:
     def __init__(__self__, *,
-                 token: pulumi.Input[_builtins.str]):
+                 token: pulumi.Input[_builtins.str],
+                 audit_log: Optional[pulumi.Input[_builtins.str]] = None,
+                 burst: Optional[pulumi.Input[_builtins.int]] = None,
+                 requests_per_second: Optional[pulumi.Input[_builtins.float]] = None):
         """
         The set of arguments for constructing a Provider resource.
         :param pulumi.Input[_builtins.str] token: Cherry Servers API token.
+        :param pulumi.Input[_builtins.str] audit_log: Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        :param pulumi.Input[_builtins.int] burst: Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        :param pulumi.Input[_builtins.float] requests_per_second: Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
         """
         pulumi.set(__self__, "token", token)
+        if audit_log is not None:
+            pulumi.set(__self__, "audit_log", audit_log)
+        if burst is None:
+            burst = 10
+        if burst is not None:
+            pulumi.set(__self__, "burst", burst)
+        if requests_per_second is None:
+            requests_per_second = 5
+        if requests_per_second is not None:
+            pulumi.set(__self__, "requests_per_second", requests_per_second)
 
     @_builtins.property
     @pulumi.getter
@@ -38,6 +54,42 @@ class ProviderArgs:
     def token(self, value: pulumi.Input[_builtins.str]):
         pulumi.set(self, "token", value)
 
+    @_builtins.property
+    @pulumi.getter(name="auditLog")
+    def audit_log(self) -> Optional[pulumi.Input[_builtins.str]]:
+        """
+        Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        """
+        return pulumi.get(self, "audit_log")
+
+    @audit_log.setter
+    def audit_log(self, value: Optional[pulumi.Input[_builtins.str]]):
+        pulumi.set(self, "audit_log", value)
+
+    @_builtins.property
+    @pulumi.getter
+    def burst(self) -> Optional[pulumi.Input[_builtins.int]]:
+        """
+        Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        """
+        return pulumi.get(self, "burst")
+
+    @burst.setter
+    def burst(self, value: Optional[pulumi.Input[_builtins.int]]):
+        pulumi.set(self, "burst", value)
+
+    @_builtins.property
+    @pulumi.getter(name="requestsPerSecond")
+    def requests_per_second(self) -> Optional[pulumi.Input[_builtins.float]]:
+        """
+        Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
+        """
+        return pulumi.get(self, "requests_per_second")
+
+    @requests_per_second.setter
+    def requests_per_second(self, value: Optional[pulumi.Input[_builtins.float]]):
+        pulumi.set(self, "requests_per_second", value)
+
 
 @pulumi.type_token("pulumi:providers:pulumi-cherry-servers")
 class Provider(pulumi.ProviderResource):
@@ -45,12 +97,18 @@ class Provider(pulumi.ProviderResource):
     def __init__(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 audit_log: Optional[pulumi.Input[_builtins.str]] = None,
+                 burst: Optional[pulumi.Input[_builtins.int]] = None,
+                 requests_per_second: Optional[pulumi.Input[_builtins.float]] = None,
                  token: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         """
         Create a Pulumi-cherry-servers resource with the given unique name, props, and options.
         :param str resource_name: The name of the resource.
         :param pulumi.ResourceOptions opts: Options for the resource.
+        :param pulumi.Input[_builtins.str] audit_log: Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        :param pulumi.Input[_builtins.int] burst: Maximum number of API requests that can be made at once, before rate limiting kicks in.
+        :param pulumi.Input[_builtins.float] requests_per_second: Maximum average number of API requests per second, shared by all resource operations. A non-positive value disables rate limiting.
         :param pulumi.Input[_builtins.str] token: Cherry Servers API token.
         """
         ...
@@ -76,6 +134,9 @@ class Provider(pulumi.ProviderResource):
     def _internal_init(__self__,
                  resource_name: str,
                  opts: Optional[pulumi.ResourceOptions] = None,
+                 audit_log: Optional[pulumi.Input[_builtins.str]] = None,
+                 burst: Optional[pulumi.Input[_builtins.int]] = None,
+                 requests_per_second: Optional[pulumi.Input[_builtins.float]] = None,
                  token: Optional[pulumi.Input[_builtins.str]] = None,
                  __props__=None):
         opts = pulumi.ResourceOptions.merge(_utilities.get_resource_opts_defaults(), opts)
@@ -86,6 +147,13 @@ class Provider(pulumi.ProviderResource):
                 raise TypeError('__props__ is only valid when passed in combination with a valid opts.id to get an existing resource')
             __props__ = ProviderArgs.__new__(ProviderArgs)
 
+            __props__.__dict__["audit_log"] = audit_log
+            if burst is None:
+                burst = 10
+            __props__.__dict__["burst"] = pulumi.Output.from_input(burst).apply(pulumi.runtime.to_json) if burst is not None else None
+            if requests_per_second is None:
+                requests_per_second = 5
+            __props__.__dict__["requests_per_second"] = pulumi.Output.from_input(requests_per_second).apply(pulumi.runtime.to_json) if requests_per_second is not None else None
             if token is None and not opts.urn:
                 raise TypeError("Missing required property 'token'")
             __props__.__dict__["token"] = None if token is None else pulumi.Output.secret(token)
@@ -97,6 +165,14 @@ class Provider(pulumi.ProviderResource):
             __props__,
             opts)
 
+    @_builtins.property
+    @pulumi.getter(name="auditLog")
+    def audit_log(self) -> pulumi.Output[Optional[_builtins.str]]:
+        """
+        Path of a file to append a JSON line to for every API call that changes anything. Can also be set with the CHERRY_AUDIT_LOG environment variable. Disabled if empty.
+        """
+        return pulumi.get(self, "audit_log")
+
     @_builtins.property
     @pulumi.getter
     def token(self) -> pulumi.Output[_builtins.str]: